@@ -0,0 +1,69 @@
+# Submit a job without blocking the apply on it, and gate downstream work on
+# kubeflow_job_wait instead.
+
+resource "kubeflow_pytorch_job" "train" {
+  metadata {
+    name      = "pytorch-train"
+    namespace = "ai-training"
+  }
+
+  # Only submit the job; kubeflow_job_wait below does the waiting.
+  wait_for_completion = false
+
+  spec {
+    pytorch_replica_specs {
+      master {
+        replicas = 1
+
+        template {
+          spec {
+            container {
+              name    = "pytorch"
+              image   = "gcr.io/kubeflow-examples/mnist"
+              command = ["python", "train.py"]
+            }
+          }
+        }
+      }
+    }
+  }
+}
+
+resource "kubeflow_job_wait" "train" {
+  kind      = "PyTorchJob"
+  namespace = kubeflow_pytorch_job.train.metadata[0].namespace
+  name      = kubeflow_pytorch_job.train.metadata[0].name
+  condition = "Succeeded"
+
+  timeouts {
+    create = "4h"
+  }
+}
+
+# Anything that needs the trained model depends on the wait, not the job.
+resource "kubeflow_pytorch_job" "evaluate" {
+  depends_on = [kubeflow_job_wait.train]
+
+  metadata {
+    name      = "pytorch-evaluate"
+    namespace = "ai-training"
+  }
+
+  spec {
+    pytorch_replica_specs {
+      master {
+        replicas = 1
+
+        template {
+          spec {
+            container {
+              name    = "pytorch"
+              image   = "gcr.io/kubeflow-examples/mnist"
+              command = ["python", "evaluate.py"]
+            }
+          }
+        }
+      }
+    }
+  }
+}
@@ -44,6 +44,13 @@ resource "kubeflow_pytorch_job" "pytorch_job" {
         replicas       = 1
         restart_policy = "Never"
 
+        accelerator {
+          type     = "nvidia"
+          count    = 8
+          shm_size = "64Gi"
+          rdma     = true
+        }
+
         template {
           spec {
             container {
@@ -65,24 +72,6 @@ resource "kubeflow_pytorch_job" "pytorch_job" {
                 value = "1"
               }
               image_pull_policy = "IfNotPresent"
-              resources {
-                limits = {
-                  "nvidia.com/gpu" = 8
-                  "rdma/hca"       = 1
-                }
-
-              }
-              security_context {
-                capabilities {
-                  add = ["IPC_LOCK"]
-                }
-
-              }
-              volume_mount {
-                mount_path = "/dev/shm"
-                name       = "cache-volume"
-              }
-
               volume_mount {
                 mount_path = "/mnt/pfs"
                 name       = "data"
@@ -91,12 +80,6 @@ resource "kubeflow_pytorch_job" "pytorch_job" {
             image_pull_secrets {
               name = "regcred"
             }
-            volume {
-              name = "cache-volume"
-              empty_dir {
-                medium = "Memory"
-              }
-            }
             volume {
               name = "data"
               persistent_volume_claim {
@@ -112,6 +95,13 @@ resource "kubeflow_pytorch_job" "pytorch_job" {
         replicas       = 1
         restart_policy = "Never"
 
+        accelerator {
+          type     = "nvidia"
+          count    = 8
+          shm_size = "64Gi"
+          rdma     = true
+        }
+
         template {
           spec {
             container {
@@ -130,24 +120,6 @@ resource "kubeflow_pytorch_job" "pytorch_job" {
 
 
               image_pull_policy = "IfNotPresent"
-              resources {
-                limits = {
-                  "nvidia.com/gpu" = 8
-                  "rdma/hca"       = 1
-                }
-
-              }
-              security_context {
-                capabilities {
-                  add = ["IPC_LOCK"]
-                }
-
-              }
-              volume_mount {
-                mount_path = "/dev/shm"
-                name       = "cache-volume"
-              }
-
               volume_mount {
                 mount_path = "/mnt/pfs"
                 name       = "data"
@@ -156,12 +128,6 @@ resource "kubeflow_pytorch_job" "pytorch_job" {
             image_pull_secrets {
               name = "regcred"
             }
-            volume {
-              name = "cache-volume"
-              empty_dir {
-                medium = "Memory"
-              }
-            }
             volume {
               name = "data"
               persistent_volume_claim {
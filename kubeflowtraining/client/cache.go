@@ -0,0 +1,208 @@
+package client
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// jobCache serves job reads from shared informers, so refreshing many jobs
+// of the same kind in a namespace costs one LIST and one WATCH instead of one
+// GET per job. Informers are started lazily on the first read of a
+// kind/namespace pair and live as long as the provider process.
+//
+// A cached object is only served once its informer has synced, while its
+// watch has delivered an event or a bookmark within maxStaleness, and never
+// for a job this client has written to until the informer has observed that
+// write; in every other case the caller falls back to a live read. A
+// namespace without any activity for maxStaleness is therefore read live.
+type jobCache struct {
+	dynamicClient dynamic.Interface
+	maxStaleness  time.Duration
+
+	mu        sync.Mutex
+	informers map[jobCacheKey]*jobInformer
+	stopCh    chan struct{}
+}
+
+type jobCacheKey struct {
+	resource  schema.GroupVersionResource
+	namespace string
+}
+
+type jobInformer struct {
+	informer cache.SharedIndexInformer
+
+	mu sync.Mutex
+	// lastSeen is when the watch last delivered something: an event, or a
+	// bookmark moving the resourceVersion the reflector last synced to.
+	lastSeen time.Time
+	// writes tracks jobs written through this client that the informer has
+	// not caught up with yet, keyed by name. The value is the resourceVersion
+	// returned by the write, or "" for a deletion.
+	writes map[string]jobWrite
+}
+
+type jobWrite struct {
+	resourceVersion string
+	at              time.Time
+}
+
+func newJobCache(dynamicClient dynamic.Interface, maxStaleness time.Duration) *jobCache {
+	return &jobCache{
+		dynamicClient: dynamicClient,
+		maxStaleness:  maxStaleness,
+		informers:     make(map[jobCacheKey]*jobInformer),
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// get returns the cached job, or false when the caller must read it live.
+func (c *jobCache) get(namespace string, name string, resource schema.GroupVersionResource) (*unstructured.Unstructured, bool) {
+	ji := c.informerFor(namespace, resource)
+	if ji == nil || !ji.fresh(name, c.maxStaleness) {
+		return nil, false
+	}
+
+	item, exists, err := ji.informer.GetStore().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		// A miss may just be a job the informer has not seen yet.
+		return nil, false
+	}
+	obj, ok := item.(*unstructured.Unstructured)
+	if !ok {
+		return nil, false
+	}
+	log.Printf("[DEBUG] Serving %s %s/%s from informer cache", resource.Resource, namespace, name)
+	return obj.DeepCopy(), true
+}
+
+// recordWrite makes reads of the job bypass the cache until the informer has
+// observed resourceVersion ("" meaning the deletion of the job).
+func (c *jobCache) recordWrite(namespace string, name string, resource schema.GroupVersionResource, resourceVersion string) {
+	c.mu.Lock()
+	ji, ok := c.informers[jobCacheKey{resource: resource, namespace: namespace}]
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	ji.mu.Lock()
+	defer ji.mu.Unlock()
+	ji.writes[name] = jobWrite{resourceVersion: resourceVersion, at: time.Now()}
+}
+
+func (c *jobCache) informerFor(namespace string, resource schema.GroupVersionResource) *jobInformer {
+	key := jobCacheKey{resource: resource, namespace: namespace}
+
+	c.mu.Lock()
+	ji, ok := c.informers[key]
+	if !ok {
+		// No resync: replaying the store through the handlers would make a
+		// stalled watch look fresh.
+		factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(c.dynamicClient, 0, namespace, nil)
+		ji = &jobInformer{
+			informer: factory.ForResource(resource).Informer(),
+			writes:   make(map[string]jobWrite),
+		}
+		ji.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    ji.observe,
+			UpdateFunc: func(_, obj interface{}) { ji.observe(obj) },
+			DeleteFunc: ji.observeDelete,
+		})
+		if err := ji.informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+			log.Printf("[DEBUG] Informer for %s in namespace %s lost its watch: %s", resource.Resource, namespace, err)
+			ji.mu.Lock()
+			ji.lastSeen = time.Time{}
+			ji.mu.Unlock()
+		}); err != nil {
+			log.Printf("[DEBUG] Unable to set watch error handler: %s", err)
+		}
+		c.informers[key] = ji
+		log.Printf("[DEBUG] Starting informer for %s in namespace %s", resource.Resource, namespace)
+		go ji.informer.Run(c.stopCh)
+		go ji.trackSyncs(c.maxStaleness/4, c.stopCh)
+	}
+	c.mu.Unlock()
+
+	// Do not wait for the initial list, which may never complete, e.g.
+	// without list and watch permissions; read live until it has.
+	if !ji.informer.HasSynced() {
+		log.Printf("[DEBUG] Informer for %s in namespace %s has not synced yet", resource.Resource, namespace)
+		return nil
+	}
+	return ji
+}
+
+// trackSyncs marks the informer as seen whenever the resourceVersion its
+// reflector last synced to moves, which bookmarks do on a watch that has no
+// events to deliver.
+func (ji *jobInformer) trackSyncs(interval time.Duration, stopCh <-chan struct{}) {
+	lastSynced := ""
+	wait.Until(func() {
+		rv := ji.informer.LastSyncResourceVersion()
+		if rv == "" || rv == lastSynced {
+			return
+		}
+		lastSynced = rv
+		ji.mu.Lock()
+		ji.lastSeen = time.Now()
+		ji.mu.Unlock()
+	}, interval, stopCh)
+}
+
+func (ji *jobInformer) observe(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	ji.mu.Lock()
+	defer ji.mu.Unlock()
+	ji.lastSeen = time.Now()
+	if w, ok := ji.writes[u.GetName()]; ok && w.resourceVersion == u.GetResourceVersion() {
+		delete(ji.writes, u.GetName())
+	}
+}
+
+func (ji *jobInformer) observeDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	ji.mu.Lock()
+	defer ji.mu.Unlock()
+	ji.lastSeen = time.Now()
+	delete(ji.writes, u.GetName())
+}
+
+// fresh reports whether the informer may serve name: it must have heard from
+// the apiserver recently and must have observed our last write to the job.
+// Writes the informer never catches up with (e.g. after a relist skipped the
+// exact resourceVersion) stop blocking the cache after maxStaleness.
+func (ji *jobInformer) fresh(name string, maxStaleness time.Duration) bool {
+	ji.mu.Lock()
+	defer ji.mu.Unlock()
+
+	if time.Since(ji.lastSeen) > maxStaleness {
+		return false
+	}
+	if w, ok := ji.writes[name]; ok {
+		if time.Since(w.at) <= maxStaleness {
+			return false
+		}
+		delete(ji.writes, name)
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var testJobResource = schema.GroupVersionResource{Group: "kubeflow.org", Version: "v1", Resource: "pytorchjobs"}
+
+func testJob(resourceVersion string) *unstructured.Unstructured {
+	job := &unstructured.Unstructured{}
+	job.SetAPIVersion("kubeflow.org/v1")
+	job.SetKind("PyTorchJob")
+	job.SetNamespace("default")
+	job.SetName("test")
+	job.SetResourceVersion(resourceVersion)
+	return job
+}
+
+func TestJobCache(t *testing.T) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		testJobResource: "PyTorchJobList",
+	}, testJob("1"))
+	// Hold the initial list back, as an apiserver that is slow or denies it
+	// would.
+	listed := make(chan struct{})
+	dynamicClient.PrependReactor("list", "pytorchjobs", func(k8stesting.Action) (bool, runtime.Object, error) {
+		<-listed
+		return false, nil, nil
+	})
+
+	c := newJobCache(dynamicClient, time.Minute)
+	defer close(c.stopCh)
+
+	start := time.Now()
+	if _, ok := c.get("default", "test", testJobResource); ok {
+		t.Fatal("expected no cached job before the informer has synced")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected reads not to wait for the informer to sync, waited %s", elapsed)
+	}
+
+	close(listed)
+	eventually := func(what string, condition func() bool) {
+		t.Helper()
+		if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) { return condition(), nil }); err != nil {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+	}
+	eventually("the job to be cached", func() bool {
+		_, ok := c.get("default", "test", testJobResource)
+		return ok
+	})
+
+	// A write through the client bypasses the cache until it is observed.
+	c.recordWrite("default", "test", testJobResource, "2")
+	if _, ok := c.get("default", "test", testJobResource); ok {
+		t.Fatal("expected a write not observed yet to bypass the cache")
+	}
+	eventually("the write to be observed", func() bool {
+		if _, err := dynamicClient.Resource(testJobResource).Namespace("default").Update(context.Background(), testJob("2"), metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		obj, ok := c.get("default", "test", testJobResource)
+		return ok && obj.GetResourceVersion() == "2"
+	})
+
+	// Without watch traffic for maxStaleness, reads go live again.
+	ji := c.informers[jobCacheKey{resource: testJobResource, namespace: "default"}]
+	ji.mu.Lock()
+	ji.lastSeen = time.Now().Add(-2 * time.Minute)
+	ji.mu.Unlock()
+	if _, ok := c.get("default", "test", testJobResource); ok {
+		t.Fatal("expected a stale informer not to serve reads")
+	}
+}
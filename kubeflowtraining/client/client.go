@@ -20,15 +20,24 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"strings"
+	"time"
 
+	mpiv2beta1 "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 )
 
@@ -36,85 +45,124 @@ import (
 
 type Client interface {
 	// PyTorchJob CRUD operations
-	CreatePyTorchJob(job *kubeflowv1.PyTorchJob) error
-	GetPyTorchJob(namespace string, name string) (*kubeflowv1.PyTorchJob, error)
-	UpdatePyTorchJob(namespace string, name string, job *kubeflowv1.PyTorchJob, data []byte) error
-	DeletePyTorchJob(namespace string, name string) error
+	CreatePyTorchJob(ctx context.Context, job *kubeflowv1.PyTorchJob) error
+	GetPyTorchJob(ctx context.Context, namespace string, name string) (*kubeflowv1.PyTorchJob, error)
+	UpdatePyTorchJob(ctx context.Context, namespace string, name string, job *kubeflowv1.PyTorchJob, data []byte) error
+	DeletePyTorchJob(ctx context.Context, namespace string, name string, options metav1.DeleteOptions) error
+	WatchPyTorchJob(ctx context.Context, namespace string, name string, resourceVersion string) (watch.Interface, error)
 
 	// generate TFJob, MPIJob, XGBoostJob, PaddleJob CRUD
-	CreateTFJob(job *kubeflowv1.TFJob) error
-	GetTFJob(namespace string, name string) (*kubeflowv1.TFJob, error)
-	UpdateTFJob(namespace string, name string, job *kubeflowv1.TFJob, data []byte) error
-	DeleteTFJob(namespace string, name string) error
-
-	CreateMPIJob(job *kubeflowv1.MPIJob) error
-	GetMPIJob(namespace string, name string) (*kubeflowv1.MPIJob, error)
-	UpdateMPIJob(namespace string, name string, job *kubeflowv1.MPIJob, data []byte) error
-	DeleteMPIJob(namespace string, name string) error
-
-	CreateXGBoostJob(job *kubeflowv1.XGBoostJob) error
-	GetXGBoostJob(namespace string, name string) (*kubeflowv1.XGBoostJob, error)
-	UpdateXGBoostJob(namespace string, name string, job *kubeflowv1.XGBoostJob, data []byte) error
-	DeleteXGBoostJob(namespace string, name string) error
-
-	CreatePaddleJob(job *kubeflowv1.PaddleJob) error
-	GetPaddleJob(namespace string, name string) (*kubeflowv1.PaddleJob, error)
-	UpdatePaddleJob(namespace string, name string, job *kubeflowv1.PaddleJob, data []byte) error
-	DeletePaddleJob(namespace string, name string) error
+	CreateTFJob(ctx context.Context, job *kubeflowv1.TFJob) error
+	GetTFJob(ctx context.Context, namespace string, name string) (*kubeflowv1.TFJob, error)
+	UpdateTFJob(ctx context.Context, namespace string, name string, job *kubeflowv1.TFJob, data []byte) error
+	DeleteTFJob(ctx context.Context, namespace string, name string, options metav1.DeleteOptions) error
+	WatchTFJob(ctx context.Context, namespace string, name string, resourceVersion string) (watch.Interface, error)
+
+	CreateMPIJob(ctx context.Context, job *mpiv2beta1.MPIJob) error
+	GetMPIJob(ctx context.Context, namespace string, name string) (*mpiv2beta1.MPIJob, error)
+	UpdateMPIJob(ctx context.Context, namespace string, name string, job *mpiv2beta1.MPIJob, data []byte) error
+	DeleteMPIJob(ctx context.Context, namespace string, name string, options metav1.DeleteOptions) error
+	WatchMPIJob(ctx context.Context, namespace string, name string, resourceVersion string) (watch.Interface, error)
+
+	CreateXGBoostJob(ctx context.Context, job *kubeflowv1.XGBoostJob) error
+	GetXGBoostJob(ctx context.Context, namespace string, name string) (*kubeflowv1.XGBoostJob, error)
+	UpdateXGBoostJob(ctx context.Context, namespace string, name string, job *kubeflowv1.XGBoostJob, data []byte) error
+	DeleteXGBoostJob(ctx context.Context, namespace string, name string, options metav1.DeleteOptions) error
+	WatchXGBoostJob(ctx context.Context, namespace string, name string, resourceVersion string) (watch.Interface, error)
+
+	CreatePaddleJob(ctx context.Context, job *kubeflowv1.PaddleJob) error
+	GetPaddleJob(ctx context.Context, namespace string, name string) (*kubeflowv1.PaddleJob, error)
+	UpdatePaddleJob(ctx context.Context, namespace string, name string, job *kubeflowv1.PaddleJob, data []byte) error
+	DeletePaddleJob(ctx context.Context, namespace string, name string, options metav1.DeleteOptions) error
+	WatchPaddleJob(ctx context.Context, namespace string, name string, resourceVersion string) (watch.Interface, error)
+
+	// Pods and events of training jobs
+	ListPods(ctx context.Context, namespace string, labelSelector string) ([]corev1.Pod, error)
+	ListEvents(ctx context.Context, namespace string, fieldSelector string) ([]corev1.Event, error)
+	GetPodLogs(ctx context.Context, namespace string, name string, options *corev1.PodLogOptions) (string, error)
+
+	// Nodes and the pods running on them, to size jobs against
+	ListNodes(ctx context.Context, labelSelector string) ([]corev1.Node, error)
+	ListNonTerminatedPods(ctx context.Context) ([]corev1.Pod, error)
+
+	// Namespace policies jobs are checked against before they are submitted
+	ListResourceQuotas(ctx context.Context, namespace string) ([]corev1.ResourceQuota, error)
+	ListLimitRanges(ctx context.Context, namespace string) ([]corev1.LimitRange, error)
+
+	// Volcano queues, which are cluster scoped and have no Go types vendored
+	CreateVolcanoQueue(ctx context.Context, queue *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	GetVolcanoQueue(ctx context.Context, name string) (*unstructured.Unstructured, error)
+	UpdateVolcanoQueue(ctx context.Context, name string, data []byte) (*unstructured.Unstructured, error)
+	DeleteVolcanoQueue(ctx context.Context, name string) error
+
+	// Kueue objects, addressed by their resource name, e.g. clusterqueues.
+	// Cluster scoped objects take an empty namespace.
+	CreateKueueObject(ctx context.Context, resource string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	GetKueueObject(ctx context.Context, resource string, namespace string, name string) (*unstructured.Unstructured, error)
+	UpdateKueueObject(ctx context.Context, resource string, namespace string, name string, data []byte) (*unstructured.Unstructured, error)
+	DeleteKueueObject(ctx context.Context, resource string, namespace string, name string) error
+	ListKueueObjects(ctx context.Context, resource string, namespace string) ([]unstructured.Unstructured, error)
+
+	// Scheduling objects referenced by jobs
+	GetPriorityClass(ctx context.Context, name string) (*schedulingv1.PriorityClass, error)
 }
 
 type client struct {
 	dynamicClient dynamic.Interface
+	// coreClient serves the pods/log subresource, which is plain text and
+	// cannot be read through the dynamic client.
+	coreClient kubernetes.Interface
+	cache      *jobCache
 }
 
 // CreateMPIJob implements Client
-func (c *client) CreateMPIJob(mpij *kubeflowv1.MPIJob) error {
+func (c *client) CreateMPIJob(ctx context.Context, mpij *mpiv2beta1.MPIJob) error {
 	mpijUpdateTypeMeta(mpij)
-	return c.createResource(mpij, mpij.Namespace, mpijRes())
+	return c.createResource(ctx, mpij, mpij.Namespace, mpijRes())
 }
 
 // CreatePaddleJob implements Client
-func (c *client) CreatePaddleJob(pj *kubeflowv1.PaddleJob) error {
+func (c *client) CreatePaddleJob(ctx context.Context, pj *kubeflowv1.PaddleJob) error {
 	pjUpdateTypeMeta(pj)
-	return c.createResource(pj, pj.Namespace, pjRes())
+	return c.createResource(ctx, pj, pj.Namespace, pjRes())
 }
 
 // CreateTFJob implements Client
-func (c *client) CreateTFJob(tfj *kubeflowv1.TFJob) error {
+func (c *client) CreateTFJob(ctx context.Context, tfj *kubeflowv1.TFJob) error {
 	tfjUpdateTypeMeta(tfj)
-	return c.createResource(tfj, tfj.Namespace, tfjRes())
+	return c.createResource(ctx, tfj, tfj.Namespace, tfjRes())
 }
 
 // CreateXGBoostJob implements Client
-func (c *client) CreateXGBoostJob(xgbj *kubeflowv1.XGBoostJob) error {
+func (c *client) CreateXGBoostJob(ctx context.Context, xgbj *kubeflowv1.XGBoostJob) error {
 	xgbjUpdateTypeMeta(xgbj)
-	return c.createResource(xgbj, xgbj.Namespace, xgbjRes())
+	return c.createResource(ctx, xgbj, xgbj.Namespace, xgbjRes())
 }
 
 // DeleteMPIJob implements Client
-func (c *client) DeleteMPIJob(namespace string, name string) error {
-	return c.deleteResource(namespace, name, mpijRes())
+func (c *client) DeleteMPIJob(ctx context.Context, namespace string, name string, options metav1.DeleteOptions) error {
+	return c.deleteResource(ctx, namespace, name, mpijRes(), options)
 }
 
 // DeletePaddleJob implements Client
-func (c *client) DeletePaddleJob(namespace string, name string) error {
-	return c.deleteResource(namespace, name, pjRes())
+func (c *client) DeletePaddleJob(ctx context.Context, namespace string, name string, options metav1.DeleteOptions) error {
+	return c.deleteResource(ctx, namespace, name, pjRes(), options)
 }
 
 // DeleteTFJob implements Client
-func (c *client) DeleteTFJob(namespace string, name string) error {
-	return c.deleteResource(namespace, name, tfjRes())
+func (c *client) DeleteTFJob(ctx context.Context, namespace string, name string, options metav1.DeleteOptions) error {
+	return c.deleteResource(ctx, namespace, name, tfjRes(), options)
 }
 
 // DeleteXGBoostJob implements Client
-func (c *client) DeleteXGBoostJob(namespace string, name string) error {
-	return c.deleteResource(namespace, name, xgbjRes())
+func (c *client) DeleteXGBoostJob(ctx context.Context, namespace string, name string, options metav1.DeleteOptions) error {
+	return c.deleteResource(ctx, namespace, name, xgbjRes(), options)
 }
 
 // GetMPIJob implements Client
-func (c *client) GetMPIJob(namespace string, name string) (*kubeflowv1.MPIJob, error) {
-	var mpij kubeflowv1.MPIJob
-	resp, err := c.getResource(namespace, name, mpijRes())
+func (c *client) GetMPIJob(ctx context.Context, namespace string, name string) (*mpiv2beta1.MPIJob, error) {
+	var mpij mpiv2beta1.MPIJob
+	resp, err := c.getResource(ctx, namespace, name, mpijRes())
 	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Printf("[Warning] MPIJob %s not found (namespace=%s)", name, namespace)
@@ -134,9 +182,9 @@ func (c *client) GetMPIJob(namespace string, name string) (*kubeflowv1.MPIJob, e
 }
 
 // GetPaddleJob implements Client
-func (c *client) GetPaddleJob(namespace string, name string) (*kubeflowv1.PaddleJob, error) {
+func (c *client) GetPaddleJob(ctx context.Context, namespace string, name string) (*kubeflowv1.PaddleJob, error) {
 	var pj kubeflowv1.PaddleJob
-	resp, err := c.getResource(namespace, name, pjRes())
+	resp, err := c.getResource(ctx, namespace, name, pjRes())
 	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Printf("[Warning] PaddleJob %s not found (namespace=%s)", name, namespace)
@@ -156,9 +204,9 @@ func (c *client) GetPaddleJob(namespace string, name string) (*kubeflowv1.Paddle
 }
 
 // GetTFJob implements Client
-func (c *client) GetTFJob(namespace string, name string) (*kubeflowv1.TFJob, error) {
+func (c *client) GetTFJob(ctx context.Context, namespace string, name string) (*kubeflowv1.TFJob, error) {
 	var tfj kubeflowv1.TFJob
-	resp, err := c.getResource(namespace, name, tfjRes())
+	resp, err := c.getResource(ctx, namespace, name, tfjRes())
 	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Printf("[Warning] TFJob %s not found (namespace=%s)", name, namespace)
@@ -178,9 +226,9 @@ func (c *client) GetTFJob(namespace string, name string) (*kubeflowv1.TFJob, err
 }
 
 // GetXGBoostJob implements Client
-func (c *client) GetXGBoostJob(namespace string, name string) (*kubeflowv1.XGBoostJob, error) {
+func (c *client) GetXGBoostJob(ctx context.Context, namespace string, name string) (*kubeflowv1.XGBoostJob, error) {
 	var xgbj kubeflowv1.XGBoostJob
-	resp, err := c.getResource(namespace, name, xgbjRes())
+	resp, err := c.getResource(ctx, namespace, name, xgbjRes())
 	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Printf("[Warning] XGBoostJob %s not found (namespace=%s)", name, namespace)
@@ -200,44 +248,64 @@ func (c *client) GetXGBoostJob(namespace string, name string) (*kubeflowv1.XGBoo
 }
 
 // UpdateMPIJob implements Client
-func (c *client) UpdateMPIJob(namespace string, name string, job *kubeflowv1.MPIJob, data []byte) error {
+func (c *client) UpdateMPIJob(ctx context.Context, namespace string, name string, job *mpiv2beta1.MPIJob, data []byte) error {
 	mpijUpdateTypeMeta(job)
-	return c.updateResource(namespace, name, mpijRes(), job, data)
+	return c.updateResource(ctx, namespace, name, mpijRes(), job, data)
 }
 
 // UpdatePaddleJob implements Client
-func (c *client) UpdatePaddleJob(namespace string, name string, job *kubeflowv1.PaddleJob, data []byte) error {
+func (c *client) UpdatePaddleJob(ctx context.Context, namespace string, name string, job *kubeflowv1.PaddleJob, data []byte) error {
 	pjUpdateTypeMeta(job)
-	return c.updateResource(namespace, name, pjRes(), job, data)
+	return c.updateResource(ctx, namespace, name, pjRes(), job, data)
 }
 
 // UpdateTFJob implements Client
-func (c *client) UpdateTFJob(namespace string, name string, job *kubeflowv1.TFJob, data []byte) error {
+func (c *client) UpdateTFJob(ctx context.Context, namespace string, name string, job *kubeflowv1.TFJob, data []byte) error {
 	tfjUpdateTypeMeta(job)
-	return c.updateResource(namespace, name, tfjRes(), job, data)
+	return c.updateResource(ctx, namespace, name, tfjRes(), job, data)
 }
 
 // UpdateXGBoostJob implements Client
-func (c *client) UpdateXGBoostJob(namespace string, name string, job *kubeflowv1.XGBoostJob, data []byte) error {
+func (c *client) UpdateXGBoostJob(ctx context.Context, namespace string, name string, job *kubeflowv1.XGBoostJob, data []byte) error {
 	xgbjUpdateTypeMeta(job)
-	return c.updateResource(namespace, name, xgbjRes(), job, data)
+	return c.updateResource(ctx, namespace, name, xgbjRes(), job, data)
+}
+
+// WatchMPIJob implements Client
+func (c *client) WatchMPIJob(ctx context.Context, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+	return c.watchResource(ctx, namespace, name, resourceVersion, mpijRes(), func() runtime.Object { return &mpiv2beta1.MPIJob{} })
+}
+
+// WatchPaddleJob implements Client
+func (c *client) WatchPaddleJob(ctx context.Context, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+	return c.watchResource(ctx, namespace, name, resourceVersion, pjRes(), func() runtime.Object { return &kubeflowv1.PaddleJob{} })
+}
+
+// WatchTFJob implements Client
+func (c *client) WatchTFJob(ctx context.Context, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+	return c.watchResource(ctx, namespace, name, resourceVersion, tfjRes(), func() runtime.Object { return &kubeflowv1.TFJob{} })
+}
+
+// WatchXGBoostJob implements Client
+func (c *client) WatchXGBoostJob(ctx context.Context, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+	return c.watchResource(ctx, namespace, name, resourceVersion, xgbjRes(), func() runtime.Object { return &kubeflowv1.XGBoostJob{} })
 }
 
 // CreatePyTorchJob implements Client
-func (c *client) CreatePyTorchJob(ptj *kubeflowv1.PyTorchJob) error {
+func (c *client) CreatePyTorchJob(ctx context.Context, ptj *kubeflowv1.PyTorchJob) error {
 	ptjUpdateTypeMeta(ptj)
-	return c.createResource(ptj, ptj.Namespace, ptjRes())
+	return c.createResource(ctx, ptj, ptj.Namespace, ptjRes())
 }
 
 // DeletePyTorchJob implements Client
-func (c *client) DeletePyTorchJob(namespace string, name string) error {
-	return c.deleteResource(namespace, name, ptjRes())
+func (c *client) DeletePyTorchJob(ctx context.Context, namespace string, name string, options metav1.DeleteOptions) error {
+	return c.deleteResource(ctx, namespace, name, ptjRes(), options)
 }
 
 // GetPyTorchJob implements Client
-func (c *client) GetPyTorchJob(namespace string, name string) (*kubeflowv1.PyTorchJob, error) {
+func (c *client) GetPyTorchJob(ctx context.Context, namespace string, name string) (*kubeflowv1.PyTorchJob, error) {
 	var ptj kubeflowv1.PyTorchJob
-	resp, err := c.getResource(namespace, name, ptjRes())
+	resp, err := c.getResource(ctx, namespace, name, ptjRes())
 	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Printf("[Warning] PyTorchJob %s not found (namespace=%s)", name, namespace)
@@ -257,9 +325,145 @@ func (c *client) GetPyTorchJob(namespace string, name string) (*kubeflowv1.PyTor
 }
 
 // UpdatePyTorchJob implements Client
-func (c *client) UpdatePyTorchJob(namespace string, name string, job *kubeflowv1.PyTorchJob, data []byte) error {
+func (c *client) UpdatePyTorchJob(ctx context.Context, namespace string, name string, job *kubeflowv1.PyTorchJob, data []byte) error {
 	ptjUpdateTypeMeta(job)
-	return c.updateResource(namespace, name, ptjRes(), job, data)
+	return c.updateResource(ctx, namespace, name, ptjRes(), job, data)
+}
+
+// WatchPyTorchJob implements Client
+func (c *client) WatchPyTorchJob(ctx context.Context, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+	return c.watchResource(ctx, namespace, name, resourceVersion, ptjRes(), func() runtime.Object { return &kubeflowv1.PyTorchJob{} })
+}
+
+// ListPods implements Client
+func (c *client) ListPods(ctx context.Context, namespace string, labelSelector string) ([]corev1.Pod, error) {
+	resp, err := c.listResource(ctx, namespace, podRes(), metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to list pods, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return nil, fmt.Errorf(msg)
+	}
+	pods := make([]corev1.Pod, len(resp.Items))
+	for i, item := range resp.Items {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &pods[i]); err != nil {
+			msg := fmt.Sprintf("Failed to translate unstructed to Pod, with error: %v", err)
+			log.Printf("[Error] %s", msg)
+			return nil, fmt.Errorf(msg)
+		}
+	}
+	return pods, nil
+}
+
+// GetPodLogs implements Client
+func (c *client) GetPodLogs(ctx context.Context, namespace string, name string, options *corev1.PodLogOptions) (string, error) {
+	data, err := c.coreClient.CoreV1().Pods(namespace).GetLogs(name, options).DoRaw(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to get logs of pod %s, with error: %v", name, err)
+		log.Printf("[Error] %s", msg)
+		return "", fmt.Errorf(msg)
+	}
+	return string(data), nil
+}
+
+// ListEvents implements Client
+func (c *client) ListEvents(ctx context.Context, namespace string, fieldSelector string) ([]corev1.Event, error) {
+	resp, err := c.listResource(ctx, namespace, eventRes(), metav1.ListOptions{FieldSelector: fieldSelector})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to list events, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return nil, fmt.Errorf(msg)
+	}
+	events := make([]corev1.Event, len(resp.Items))
+	for i, item := range resp.Items {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &events[i]); err != nil {
+			msg := fmt.Sprintf("Failed to translate unstructed to Event, with error: %v", err)
+			log.Printf("[Error] %s", msg)
+			return nil, fmt.Errorf(msg)
+		}
+	}
+	return events, nil
+}
+
+// ListNodes implements Client
+func (c *client) ListNodes(ctx context.Context, labelSelector string) ([]corev1.Node, error) {
+	resp, err := c.dynamicClient.Resource(nodeRes()).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to list nodes, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return nil, fmt.Errorf(msg)
+	}
+	nodes := make([]corev1.Node, len(resp.Items))
+	for i, item := range resp.Items {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &nodes[i]); err != nil {
+			msg := fmt.Sprintf("Failed to translate unstructed to Node, with error: %v", err)
+			log.Printf("[Error] %s", msg)
+			return nil, fmt.Errorf(msg)
+		}
+	}
+	return nodes, nil
+}
+
+// ListNonTerminatedPods implements Client. It lists the pods of all
+// namespaces but those that terminated, which no longer hold resources of
+// their node.
+func (c *client) ListNonTerminatedPods(ctx context.Context) ([]corev1.Pod, error) {
+	fieldSelector := fields.AndSelectors(
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+	).String()
+	resp, err := c.listResource(ctx, metav1.NamespaceAll, podRes(), metav1.ListOptions{FieldSelector: fieldSelector})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to list non-terminated pods, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return nil, fmt.Errorf(msg)
+	}
+	pods := make([]corev1.Pod, len(resp.Items))
+	for i, item := range resp.Items {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &pods[i]); err != nil {
+			msg := fmt.Sprintf("Failed to translate unstructed to Pod, with error: %v", err)
+			log.Printf("[Error] %s", msg)
+			return nil, fmt.Errorf(msg)
+		}
+	}
+	return pods, nil
+}
+
+// ListResourceQuotas implements Client
+func (c *client) ListResourceQuotas(ctx context.Context, namespace string) ([]corev1.ResourceQuota, error) {
+	resp, err := c.listResource(ctx, namespace, resourceQuotaRes(), metav1.ListOptions{})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to list resource quotas, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return nil, fmt.Errorf(msg)
+	}
+	quotas := make([]corev1.ResourceQuota, len(resp.Items))
+	for i, item := range resp.Items {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &quotas[i]); err != nil {
+			msg := fmt.Sprintf("Failed to translate unstructed to ResourceQuota, with error: %v", err)
+			log.Printf("[Error] %s", msg)
+			return nil, fmt.Errorf(msg)
+		}
+	}
+	return quotas, nil
+}
+
+// ListLimitRanges implements Client
+func (c *client) ListLimitRanges(ctx context.Context, namespace string) ([]corev1.LimitRange, error) {
+	resp, err := c.listResource(ctx, namespace, limitRangeRes(), metav1.ListOptions{})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to list limit ranges, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return nil, fmt.Errorf(msg)
+	}
+	limitRanges := make([]corev1.LimitRange, len(resp.Items))
+	for i, item := range resp.Items {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), &limitRanges[i]); err != nil {
+			msg := fmt.Sprintf("Failed to translate unstructed to LimitRange, with error: %v", err)
+			log.Printf("[Error] %s", msg)
+			return nil, fmt.Errorf(msg)
+		}
+	}
+	return limitRanges, nil
 }
 
 func ptjUpdateTypeMeta(job *kubeflowv1.PyTorchJob) {
@@ -292,19 +496,15 @@ func tfjRes() schema.GroupVersionResource {
 	}
 }
 
-func mpijUpdateTypeMeta(job *kubeflowv1.MPIJob) {
+func mpijUpdateTypeMeta(job *mpiv2beta1.MPIJob) {
 	job.TypeMeta = metav1.TypeMeta{
-		Kind:       "MPIJob",
-		APIVersion: kubeflowv1.GroupVersion.String(),
+		Kind:       mpiv2beta1.Kind,
+		APIVersion: mpiv2beta1.SchemeGroupVersion.String(),
 	}
 }
 
 func mpijRes() schema.GroupVersionResource {
-	return schema.GroupVersionResource{
-		Group:    kubeflowv1.GroupVersion.Group,
-		Version:  kubeflowv1.GroupVersion.Version,
-		Resource: "mpijobs",
-	}
+	return mpiv2beta1.SchemeGroupVersion.WithResource("mpijobs")
 }
 
 func xgbjUpdateTypeMeta(job *kubeflowv1.XGBoostJob) {
@@ -337,6 +537,26 @@ func pjRes() schema.GroupVersionResource {
 	}
 }
 
+func podRes() schema.GroupVersionResource {
+	return corev1.SchemeGroupVersion.WithResource("pods")
+}
+
+func eventRes() schema.GroupVersionResource {
+	return corev1.SchemeGroupVersion.WithResource("events")
+}
+
+func nodeRes() schema.GroupVersionResource {
+	return corev1.SchemeGroupVersion.WithResource("nodes")
+}
+
+func resourceQuotaRes() schema.GroupVersionResource {
+	return corev1.SchemeGroupVersion.WithResource("resourcequotas")
+}
+
+func limitRangeRes() schema.GroupVersionResource {
+	return corev1.SchemeGroupVersion.WithResource("limitranges")
+}
+
 // New creates our client wrapper object for the actual kubeVirt and kubernetes clients we use.
 func NewClient(cfg *restclient.Config) (Client, error) {
 	result := &client{}
@@ -347,11 +567,31 @@ func NewClient(cfg *restclient.Config) (Client, error) {
 		return nil, fmt.Errorf(msg)
 	}
 	result.dynamicClient = c
+	cc, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to create client, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return nil, fmt.Errorf(msg)
+	}
+	result.coreClient = cc
 	return result, nil
 }
 
+// NewClientWithCache creates a client that serves job reads from shared
+// informers, falling back to the apiserver when the cache may be older than
+// maxStaleness or has not yet observed a write made through this client.
+func NewClientWithCache(cfg *restclient.Config, maxStaleness time.Duration) (Client, error) {
+	result, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c := result.(*client)
+	c.cache = newJobCache(c.dynamicClient, maxStaleness)
+	return c, nil
+}
+
 // Generic Resource CRUD operations
-func (c *client) createResource(obj interface{}, namespace string, resource schema.GroupVersionResource) error {
+func (c *client) createResource(ctx context.Context, obj interface{}, namespace string, resource schema.GroupVersionResource) error {
 	resultMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
 	if err != nil {
 		msg := fmt.Sprintf("Failed to translate %s to Unstructed (for create operation), with error: %v", resource.Resource, err)
@@ -360,31 +600,100 @@ func (c *client) createResource(obj interface{}, namespace string, resource sche
 	}
 	input := unstructured.Unstructured{}
 	input.SetUnstructuredContent(resultMap)
-	resp, err := c.dynamicClient.Resource(resource).Namespace(namespace).Create(context.Background(), &input, metav1.CreateOptions{})
+	resp, err := c.dynamicClient.Resource(resource).Namespace(namespace).Create(ctx, &input, metav1.CreateOptions{})
 	if err != nil {
-		msg := fmt.Sprintf("Failed to create %s, with error: %v", resource.Resource, err)
-		log.Printf("[Error] %s", msg)
-		return fmt.Errorf(msg)
+		log.Printf("[Error] Failed to create %s, with error: %v", resource.Resource, err)
+		// Wrapped so that callers can still tell an AlreadyExists error apart.
+		return fmt.Errorf("Failed to create %s, with error: %w", resource.Resource, err)
+	}
+	if c.cache != nil {
+		c.cache.recordWrite(namespace, resp.GetName(), resource, resp.GetResourceVersion())
 	}
 	unstructured := resp.UnstructuredContent()
 	return runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured, obj)
 }
 
-func (c *client) getResource(namespace string, name string, resource schema.GroupVersionResource) (*unstructured.Unstructured, error) {
-	return c.dynamicClient.Resource(resource).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+func (c *client) getResource(ctx context.Context, namespace string, name string, resource schema.GroupVersionResource) (*unstructured.Unstructured, error) {
+	if c.cache != nil {
+		if obj, ok := c.cache.get(namespace, name, resource); ok {
+			return obj, nil
+		}
+	}
+	return c.dynamicClient.Resource(resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
-func (c *client) updateResource(namespace string, name string, resource schema.GroupVersionResource, obj interface{}, data []byte) error {
-	resp, err := c.dynamicClient.Resource(resource).Namespace(namespace).Patch(context.Background(), name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+func (c *client) listResource(ctx context.Context, namespace string, resource schema.GroupVersionResource, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return c.dynamicClient.Resource(resource).Namespace(namespace).List(ctx, opts)
+}
+
+func (c *client) updateResource(ctx context.Context, namespace string, name string, resource schema.GroupVersionResource, obj interface{}, data []byte) error {
+	resp, err := c.dynamicClient.Resource(resource).Namespace(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
+		if IsConflict(err) {
+			return err
+		}
 		msg := fmt.Sprintf("Failed to update %s, with error: %v", resource.Resource, err)
 		log.Printf("[Error] %s", msg)
 		return fmt.Errorf(msg)
 	}
+	if c.cache != nil {
+		c.cache.recordWrite(namespace, name, resource, resp.GetResourceVersion())
+	}
 	unstructured := resp.UnstructuredContent()
 	return runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured, obj)
 }
 
-func (c *client) deleteResource(namespace string, name string, resource schema.GroupVersionResource) error {
-	return c.dynamicClient.Resource(resource).Namespace(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+// IsConflict reports whether an update was rejected because the object
+// changed since the resourceVersion the update was tested against.
+func IsConflict(err error) bool {
+	if errors.IsConflict(err) {
+		return true
+	}
+	// A failed JSON patch test operation is rejected as unprocessable.
+	status, ok := err.(errors.APIStatus)
+	return ok && status.Status().Code == http.StatusUnprocessableEntity && strings.Contains(status.Status().Message, "testing value")
+}
+
+func (c *client) deleteResource(ctx context.Context, namespace string, name string, resource schema.GroupVersionResource, options metav1.DeleteOptions) error {
+	if err := c.dynamicClient.Resource(resource).Namespace(namespace).Delete(ctx, name, options); err != nil {
+		return err
+	}
+	if c.cache != nil {
+		c.cache.recordWrite(namespace, name, resource, "")
+	}
+	return nil
+}
+
+// watchResource watches a single named object, starting after resourceVersion
+// (or at the current state when it is empty). Objects delivered on the returned
+// watch are converted from unstructured to the type returned by newObj; error
+// events are passed through untouched so callers can detect expired watches.
+func (c *client) watchResource(ctx context.Context, namespace string, name string, resourceVersion string, resource schema.GroupVersionResource, newObj func() runtime.Object) (watch.Interface, error) {
+	w, err := c.dynamicClient.Resource(resource).Namespace(namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector:       fields.OneTermEqualSelector("metadata.name", name).String(),
+		ResourceVersion:     resourceVersion,
+		AllowWatchBookmarks: true,
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to watch %s, with error: %v", resource.Resource, err)
+		log.Printf("[Error] %s", msg)
+		return nil, fmt.Errorf(msg)
+	}
+	return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+		if in.Type == watch.Error {
+			return in, true
+		}
+		u, ok := in.Object.(*unstructured.Unstructured)
+		if !ok {
+			return in, true
+		}
+		obj := newObj()
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), obj); err != nil {
+			msg := fmt.Sprintf("Failed to translate unstructed to %s, with error: %v", resource.Resource, err)
+			log.Printf("[Error] %s", msg)
+			return watch.Event{Type: watch.Error, Object: &errors.NewInternalError(fmt.Errorf(msg)).ErrStatus}, true
+		}
+		in.Object = obj
+		return in, true
+	}), nil
 }
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// KueueGroupVersion is the API version of Kueue objects.
+var KueueGroupVersion = schema.GroupVersion{Group: "kueue.x-k8s.io", Version: "v1beta1"}
+
+func (c *client) kueueResource(resource string, namespace string) dynamic.ResourceInterface {
+	res := c.dynamicClient.Resource(KueueGroupVersion.WithResource(resource))
+	if namespace == "" {
+		return res
+	}
+	return res.Namespace(namespace)
+}
+
+// CreateKueueObject implements Client
+func (c *client) CreateKueueObject(ctx context.Context, resource string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	obj.SetAPIVersion(KueueGroupVersion.String())
+	resp, err := c.kueueResource(resource, obj.GetNamespace()).Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		log.Printf("[Error] Failed to create %s %s, with error: %v", resource, obj.GetName(), err)
+		return nil, fmt.Errorf("Failed to create %s %s, with error: %w", resource, obj.GetName(), err)
+	}
+	return resp, nil
+}
+
+// GetKueueObject implements Client
+func (c *client) GetKueueObject(ctx context.Context, resource string, namespace string, name string) (*unstructured.Unstructured, error) {
+	resp, err := c.kueueResource(resource, namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get %s %s, with error: %w", resource, name, err)
+	}
+	return resp, nil
+}
+
+// UpdateKueueObject implements Client
+func (c *client) UpdateKueueObject(ctx context.Context, resource string, namespace string, name string, data []byte) (*unstructured.Unstructured, error) {
+	resp, err := c.kueueResource(resource, namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.Printf("[Error] Failed to update %s %s, with error: %v", resource, name, err)
+		return nil, fmt.Errorf("Failed to update %s %s, with error: %w", resource, name, err)
+	}
+	return resp, nil
+}
+
+// DeleteKueueObject implements Client
+func (c *client) DeleteKueueObject(ctx context.Context, resource string, namespace string, name string) error {
+	return c.kueueResource(resource, namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// ListKueueObjects implements Client
+func (c *client) ListKueueObjects(ctx context.Context, resource string, namespace string) ([]unstructured.Unstructured, error) {
+	resp, err := c.kueueResource(resource, namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list %s, with error: %w", resource, err)
+	}
+	return resp.Items, nil
+}
@@ -5,10 +5,17 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	v2beta1 "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
 	v1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	v10 "k8s.io/api/core/v1"
+	v11 "k8s.io/api/scheduling/v1"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	watch "k8s.io/apimachinery/pkg/watch"
 )
 
 // MockClient is a mock of Client interface.
@@ -34,287 +41,615 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// CreateKueueObject mocks base method.
+func (m *MockClient) CreateKueueObject(ctx context.Context, resource string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKueueObject", ctx, resource, obj)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKueueObject indicates an expected call of CreateKueueObject.
+func (mr *MockClientMockRecorder) CreateKueueObject(ctx, resource, obj interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKueueObject", reflect.TypeOf((*MockClient)(nil).CreateKueueObject), ctx, resource, obj)
+}
+
 // CreateMPIJob mocks base method.
-func (m *MockClient) CreateMPIJob(job *v1.MPIJob) error {
+func (m *MockClient) CreateMPIJob(ctx context.Context, job *v2beta1.MPIJob) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMPIJob", job)
+	ret := m.ctrl.Call(m, "CreateMPIJob", ctx, job)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateMPIJob indicates an expected call of CreateMPIJob.
-func (mr *MockClientMockRecorder) CreateMPIJob(job interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateMPIJob(ctx, job interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMPIJob", reflect.TypeOf((*MockClient)(nil).CreateMPIJob), job)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMPIJob", reflect.TypeOf((*MockClient)(nil).CreateMPIJob), ctx, job)
 }
 
 // CreatePaddleJob mocks base method.
-func (m *MockClient) CreatePaddleJob(job *v1.PaddleJob) error {
+func (m *MockClient) CreatePaddleJob(ctx context.Context, job *v1.PaddleJob) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePaddleJob", job)
+	ret := m.ctrl.Call(m, "CreatePaddleJob", ctx, job)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePaddleJob indicates an expected call of CreatePaddleJob.
-func (mr *MockClientMockRecorder) CreatePaddleJob(job interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreatePaddleJob(ctx, job interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaddleJob", reflect.TypeOf((*MockClient)(nil).CreatePaddleJob), job)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaddleJob", reflect.TypeOf((*MockClient)(nil).CreatePaddleJob), ctx, job)
 }
 
-// CreatePytorchJob mocks base method.
-func (m *MockClient) CreatePytorchJob(job *v1.PyTorchJob) error {
+// CreatePyTorchJob mocks base method.
+func (m *MockClient) CreatePyTorchJob(ctx context.Context, job *v1.PyTorchJob) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePytorchJob", job)
+	ret := m.ctrl.Call(m, "CreatePyTorchJob", ctx, job)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreatePytorchJob indicates an expected call of CreatePytorchJob.
-func (mr *MockClientMockRecorder) CreatePytorchJob(job interface{}) *gomock.Call {
+// CreatePyTorchJob indicates an expected call of CreatePyTorchJob.
+func (mr *MockClientMockRecorder) CreatePyTorchJob(ctx, job interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePytorchJob", reflect.TypeOf((*MockClient)(nil).CreatePytorchJob), job)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePyTorchJob", reflect.TypeOf((*MockClient)(nil).CreatePyTorchJob), ctx, job)
 }
 
 // CreateTFJob mocks base method.
-func (m *MockClient) CreateTFJob(job *v1.TFJob) error {
+func (m *MockClient) CreateTFJob(ctx context.Context, job *v1.TFJob) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTFJob", job)
+	ret := m.ctrl.Call(m, "CreateTFJob", ctx, job)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateTFJob indicates an expected call of CreateTFJob.
-func (mr *MockClientMockRecorder) CreateTFJob(job interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateTFJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTFJob", reflect.TypeOf((*MockClient)(nil).CreateTFJob), ctx, job)
+}
+
+// CreateVolcanoQueue mocks base method.
+func (m *MockClient) CreateVolcanoQueue(ctx context.Context, queue *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVolcanoQueue", ctx, queue)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVolcanoQueue indicates an expected call of CreateVolcanoQueue.
+func (mr *MockClientMockRecorder) CreateVolcanoQueue(ctx, queue interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTFJob", reflect.TypeOf((*MockClient)(nil).CreateTFJob), job)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVolcanoQueue", reflect.TypeOf((*MockClient)(nil).CreateVolcanoQueue), ctx, queue)
 }
 
 // CreateXGBoostJob mocks base method.
-func (m *MockClient) CreateXGBoostJob(job *v1.XGBoostJob) error {
+func (m *MockClient) CreateXGBoostJob(ctx context.Context, job *v1.XGBoostJob) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateXGBoostJob", job)
+	ret := m.ctrl.Call(m, "CreateXGBoostJob", ctx, job)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateXGBoostJob indicates an expected call of CreateXGBoostJob.
-func (mr *MockClientMockRecorder) CreateXGBoostJob(job interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) CreateXGBoostJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateXGBoostJob", reflect.TypeOf((*MockClient)(nil).CreateXGBoostJob), ctx, job)
+}
+
+// DeleteKueueObject mocks base method.
+func (m *MockClient) DeleteKueueObject(ctx context.Context, resource, namespace, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKueueObject", ctx, resource, namespace, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKueueObject indicates an expected call of DeleteKueueObject.
+func (mr *MockClientMockRecorder) DeleteKueueObject(ctx, resource, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateXGBoostJob", reflect.TypeOf((*MockClient)(nil).CreateXGBoostJob), job)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKueueObject", reflect.TypeOf((*MockClient)(nil).DeleteKueueObject), ctx, resource, namespace, name)
 }
 
 // DeleteMPIJob mocks base method.
-func (m *MockClient) DeleteMPIJob(namespace, name string) error {
+func (m *MockClient) DeleteMPIJob(ctx context.Context, namespace, name string, options v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMPIJob", namespace, name)
+	ret := m.ctrl.Call(m, "DeleteMPIJob", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteMPIJob indicates an expected call of DeleteMPIJob.
-func (mr *MockClientMockRecorder) DeleteMPIJob(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteMPIJob(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMPIJob", reflect.TypeOf((*MockClient)(nil).DeleteMPIJob), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMPIJob", reflect.TypeOf((*MockClient)(nil).DeleteMPIJob), ctx, namespace, name, options)
 }
 
 // DeletePaddleJob mocks base method.
-func (m *MockClient) DeletePaddleJob(namespace, name string) error {
+func (m *MockClient) DeletePaddleJob(ctx context.Context, namespace, name string, options v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePaddleJob", namespace, name)
+	ret := m.ctrl.Call(m, "DeletePaddleJob", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeletePaddleJob indicates an expected call of DeletePaddleJob.
-func (mr *MockClientMockRecorder) DeletePaddleJob(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeletePaddleJob(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaddleJob", reflect.TypeOf((*MockClient)(nil).DeletePaddleJob), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaddleJob", reflect.TypeOf((*MockClient)(nil).DeletePaddleJob), ctx, namespace, name, options)
 }
 
-// DeletePytorchJob mocks base method.
-func (m *MockClient) DeletePytorchJob(namespace, name string) error {
+// DeletePyTorchJob mocks base method.
+func (m *MockClient) DeletePyTorchJob(ctx context.Context, namespace, name string, options v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePytorchJob", namespace, name)
+	ret := m.ctrl.Call(m, "DeletePyTorchJob", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeletePytorchJob indicates an expected call of DeletePytorchJob.
-func (mr *MockClientMockRecorder) DeletePytorchJob(namespace, name interface{}) *gomock.Call {
+// DeletePyTorchJob indicates an expected call of DeletePyTorchJob.
+func (mr *MockClientMockRecorder) DeletePyTorchJob(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePytorchJob", reflect.TypeOf((*MockClient)(nil).DeletePytorchJob), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePyTorchJob", reflect.TypeOf((*MockClient)(nil).DeletePyTorchJob), ctx, namespace, name, options)
 }
 
 // DeleteTFJob mocks base method.
-func (m *MockClient) DeleteTFJob(namespace, name string) error {
+func (m *MockClient) DeleteTFJob(ctx context.Context, namespace, name string, options v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTFJob", namespace, name)
+	ret := m.ctrl.Call(m, "DeleteTFJob", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTFJob indicates an expected call of DeleteTFJob.
-func (mr *MockClientMockRecorder) DeleteTFJob(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteTFJob(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTFJob", reflect.TypeOf((*MockClient)(nil).DeleteTFJob), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTFJob", reflect.TypeOf((*MockClient)(nil).DeleteTFJob), ctx, namespace, name, options)
+}
+
+// DeleteVolcanoQueue mocks base method.
+func (m *MockClient) DeleteVolcanoQueue(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVolcanoQueue", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVolcanoQueue indicates an expected call of DeleteVolcanoQueue.
+func (mr *MockClientMockRecorder) DeleteVolcanoQueue(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVolcanoQueue", reflect.TypeOf((*MockClient)(nil).DeleteVolcanoQueue), ctx, name)
 }
 
 // DeleteXGBoostJob mocks base method.
-func (m *MockClient) DeleteXGBoostJob(namespace, name string) error {
+func (m *MockClient) DeleteXGBoostJob(ctx context.Context, namespace, name string, options v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteXGBoostJob", namespace, name)
+	ret := m.ctrl.Call(m, "DeleteXGBoostJob", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteXGBoostJob indicates an expected call of DeleteXGBoostJob.
-func (mr *MockClientMockRecorder) DeleteXGBoostJob(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) DeleteXGBoostJob(ctx, namespace, name, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteXGBoostJob", reflect.TypeOf((*MockClient)(nil).DeleteXGBoostJob), ctx, namespace, name, options)
+}
+
+// GetKueueObject mocks base method.
+func (m *MockClient) GetKueueObject(ctx context.Context, resource, namespace, name string) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKueueObject", ctx, resource, namespace, name)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKueueObject indicates an expected call of GetKueueObject.
+func (mr *MockClientMockRecorder) GetKueueObject(ctx, resource, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteXGBoostJob", reflect.TypeOf((*MockClient)(nil).DeleteXGBoostJob), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKueueObject", reflect.TypeOf((*MockClient)(nil).GetKueueObject), ctx, resource, namespace, name)
 }
 
 // GetMPIJob mocks base method.
-func (m *MockClient) GetMPIJob(namespace, name string) (*v1.MPIJob, error) {
+func (m *MockClient) GetMPIJob(ctx context.Context, namespace, name string) (*v2beta1.MPIJob, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMPIJob", namespace, name)
-	ret0, _ := ret[0].(*v1.MPIJob)
+	ret := m.ctrl.Call(m, "GetMPIJob", ctx, namespace, name)
+	ret0, _ := ret[0].(*v2beta1.MPIJob)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMPIJob indicates an expected call of GetMPIJob.
-func (mr *MockClientMockRecorder) GetMPIJob(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetMPIJob(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMPIJob", reflect.TypeOf((*MockClient)(nil).GetMPIJob), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMPIJob", reflect.TypeOf((*MockClient)(nil).GetMPIJob), ctx, namespace, name)
 }
 
 // GetPaddleJob mocks base method.
-func (m *MockClient) GetPaddleJob(namespace, name string) (*v1.PaddleJob, error) {
+func (m *MockClient) GetPaddleJob(ctx context.Context, namespace, name string) (*v1.PaddleJob, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPaddleJob", namespace, name)
+	ret := m.ctrl.Call(m, "GetPaddleJob", ctx, namespace, name)
 	ret0, _ := ret[0].(*v1.PaddleJob)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPaddleJob indicates an expected call of GetPaddleJob.
-func (mr *MockClientMockRecorder) GetPaddleJob(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetPaddleJob(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaddleJob", reflect.TypeOf((*MockClient)(nil).GetPaddleJob), ctx, namespace, name)
+}
+
+// GetPodLogs mocks base method.
+func (m *MockClient) GetPodLogs(ctx context.Context, namespace, name string, options *v10.PodLogOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodLogs", ctx, namespace, name, options)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPodLogs indicates an expected call of GetPodLogs.
+func (mr *MockClientMockRecorder) GetPodLogs(ctx, namespace, name, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodLogs", reflect.TypeOf((*MockClient)(nil).GetPodLogs), ctx, namespace, name, options)
+}
+
+// GetPriorityClass mocks base method.
+func (m *MockClient) GetPriorityClass(ctx context.Context, name string) (*v11.PriorityClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriorityClass", ctx, name)
+	ret0, _ := ret[0].(*v11.PriorityClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriorityClass indicates an expected call of GetPriorityClass.
+func (mr *MockClientMockRecorder) GetPriorityClass(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaddleJob", reflect.TypeOf((*MockClient)(nil).GetPaddleJob), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriorityClass", reflect.TypeOf((*MockClient)(nil).GetPriorityClass), ctx, name)
 }
 
-// GetPytorchJob mocks base method.
-func (m *MockClient) GetPytorchJob(namespace, name string) (*v1.PyTorchJob, error) {
+// GetPyTorchJob mocks base method.
+func (m *MockClient) GetPyTorchJob(ctx context.Context, namespace, name string) (*v1.PyTorchJob, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPytorchJob", namespace, name)
+	ret := m.ctrl.Call(m, "GetPyTorchJob", ctx, namespace, name)
 	ret0, _ := ret[0].(*v1.PyTorchJob)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPytorchJob indicates an expected call of GetPytorchJob.
-func (mr *MockClientMockRecorder) GetPytorchJob(namespace, name interface{}) *gomock.Call {
+// GetPyTorchJob indicates an expected call of GetPyTorchJob.
+func (mr *MockClientMockRecorder) GetPyTorchJob(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPytorchJob", reflect.TypeOf((*MockClient)(nil).GetPytorchJob), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPyTorchJob", reflect.TypeOf((*MockClient)(nil).GetPyTorchJob), ctx, namespace, name)
 }
 
 // GetTFJob mocks base method.
-func (m *MockClient) GetTFJob(namespace, name string) (*v1.TFJob, error) {
+func (m *MockClient) GetTFJob(ctx context.Context, namespace, name string) (*v1.TFJob, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTFJob", namespace, name)
+	ret := m.ctrl.Call(m, "GetTFJob", ctx, namespace, name)
 	ret0, _ := ret[0].(*v1.TFJob)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTFJob indicates an expected call of GetTFJob.
-func (mr *MockClientMockRecorder) GetTFJob(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetTFJob(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTFJob", reflect.TypeOf((*MockClient)(nil).GetTFJob), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTFJob", reflect.TypeOf((*MockClient)(nil).GetTFJob), ctx, namespace, name)
+}
+
+// GetVolcanoQueue mocks base method.
+func (m *MockClient) GetVolcanoQueue(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVolcanoQueue", ctx, name)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVolcanoQueue indicates an expected call of GetVolcanoQueue.
+func (mr *MockClientMockRecorder) GetVolcanoQueue(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVolcanoQueue", reflect.TypeOf((*MockClient)(nil).GetVolcanoQueue), ctx, name)
 }
 
 // GetXGBoostJob mocks base method.
-func (m *MockClient) GetXGBoostJob(namespace, name string) (*v1.XGBoostJob, error) {
+func (m *MockClient) GetXGBoostJob(ctx context.Context, namespace, name string) (*v1.XGBoostJob, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetXGBoostJob", namespace, name)
+	ret := m.ctrl.Call(m, "GetXGBoostJob", ctx, namespace, name)
 	ret0, _ := ret[0].(*v1.XGBoostJob)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetXGBoostJob indicates an expected call of GetXGBoostJob.
-func (mr *MockClientMockRecorder) GetXGBoostJob(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) GetXGBoostJob(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXGBoostJob", reflect.TypeOf((*MockClient)(nil).GetXGBoostJob), ctx, namespace, name)
+}
+
+// ListEvents mocks base method.
+func (m *MockClient) ListEvents(ctx context.Context, namespace, fieldSelector string) ([]v10.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, namespace, fieldSelector)
+	ret0, _ := ret[0].([]v10.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockClientMockRecorder) ListEvents(ctx, namespace, fieldSelector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockClient)(nil).ListEvents), ctx, namespace, fieldSelector)
+}
+
+// ListKueueObjects mocks base method.
+func (m *MockClient) ListKueueObjects(ctx context.Context, resource, namespace string) ([]unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKueueObjects", ctx, resource, namespace)
+	ret0, _ := ret[0].([]unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKueueObjects indicates an expected call of ListKueueObjects.
+func (mr *MockClientMockRecorder) ListKueueObjects(ctx, resource, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKueueObjects", reflect.TypeOf((*MockClient)(nil).ListKueueObjects), ctx, resource, namespace)
+}
+
+// ListLimitRanges mocks base method.
+func (m *MockClient) ListLimitRanges(ctx context.Context, namespace string) ([]v10.LimitRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLimitRanges", ctx, namespace)
+	ret0, _ := ret[0].([]v10.LimitRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLimitRanges indicates an expected call of ListLimitRanges.
+func (mr *MockClientMockRecorder) ListLimitRanges(ctx, namespace interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXGBoostJob", reflect.TypeOf((*MockClient)(nil).GetXGBoostJob), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLimitRanges", reflect.TypeOf((*MockClient)(nil).ListLimitRanges), ctx, namespace)
+}
+
+// ListNodes mocks base method.
+func (m *MockClient) ListNodes(ctx context.Context, labelSelector string) ([]v10.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodes", ctx, labelSelector)
+	ret0, _ := ret[0].([]v10.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodes indicates an expected call of ListNodes.
+func (mr *MockClientMockRecorder) ListNodes(ctx, labelSelector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodes", reflect.TypeOf((*MockClient)(nil).ListNodes), ctx, labelSelector)
+}
+
+// ListNonTerminatedPods mocks base method.
+func (m *MockClient) ListNonTerminatedPods(ctx context.Context) ([]v10.Pod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNonTerminatedPods", ctx)
+	ret0, _ := ret[0].([]v10.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNonTerminatedPods indicates an expected call of ListNonTerminatedPods.
+func (mr *MockClientMockRecorder) ListNonTerminatedPods(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNonTerminatedPods", reflect.TypeOf((*MockClient)(nil).ListNonTerminatedPods), ctx)
+}
+
+// ListPods mocks base method.
+func (m *MockClient) ListPods(ctx context.Context, namespace, labelSelector string) ([]v10.Pod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPods", ctx, namespace, labelSelector)
+	ret0, _ := ret[0].([]v10.Pod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPods indicates an expected call of ListPods.
+func (mr *MockClientMockRecorder) ListPods(ctx, namespace, labelSelector interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPods", reflect.TypeOf((*MockClient)(nil).ListPods), ctx, namespace, labelSelector)
+}
+
+// ListResourceQuotas mocks base method.
+func (m *MockClient) ListResourceQuotas(ctx context.Context, namespace string) ([]v10.ResourceQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourceQuotas", ctx, namespace)
+	ret0, _ := ret[0].([]v10.ResourceQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourceQuotas indicates an expected call of ListResourceQuotas.
+func (mr *MockClientMockRecorder) ListResourceQuotas(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourceQuotas", reflect.TypeOf((*MockClient)(nil).ListResourceQuotas), ctx, namespace)
+}
+
+// UpdateKueueObject mocks base method.
+func (m *MockClient) UpdateKueueObject(ctx context.Context, resource, namespace, name string, data []byte) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKueueObject", ctx, resource, namespace, name, data)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateKueueObject indicates an expected call of UpdateKueueObject.
+func (mr *MockClientMockRecorder) UpdateKueueObject(ctx, resource, namespace, name, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKueueObject", reflect.TypeOf((*MockClient)(nil).UpdateKueueObject), ctx, resource, namespace, name, data)
 }
 
 // UpdateMPIJob mocks base method.
-func (m *MockClient) UpdateMPIJob(namespace, name string, job *v1.MPIJob, data []byte) error {
+func (m *MockClient) UpdateMPIJob(ctx context.Context, namespace, name string, job *v2beta1.MPIJob, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateMPIJob", namespace, name, job, data)
+	ret := m.ctrl.Call(m, "UpdateMPIJob", ctx, namespace, name, job, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateMPIJob indicates an expected call of UpdateMPIJob.
-func (mr *MockClientMockRecorder) UpdateMPIJob(namespace, name, job, data interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) UpdateMPIJob(ctx, namespace, name, job, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMPIJob", reflect.TypeOf((*MockClient)(nil).UpdateMPIJob), namespace, name, job, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMPIJob", reflect.TypeOf((*MockClient)(nil).UpdateMPIJob), ctx, namespace, name, job, data)
 }
 
 // UpdatePaddleJob mocks base method.
-func (m *MockClient) UpdatePaddleJob(namespace, name string, job *v1.PaddleJob, data []byte) error {
+func (m *MockClient) UpdatePaddleJob(ctx context.Context, namespace, name string, job *v1.PaddleJob, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePaddleJob", namespace, name, job, data)
+	ret := m.ctrl.Call(m, "UpdatePaddleJob", ctx, namespace, name, job, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdatePaddleJob indicates an expected call of UpdatePaddleJob.
-func (mr *MockClientMockRecorder) UpdatePaddleJob(namespace, name, job, data interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) UpdatePaddleJob(ctx, namespace, name, job, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaddleJob", reflect.TypeOf((*MockClient)(nil).UpdatePaddleJob), namespace, name, job, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaddleJob", reflect.TypeOf((*MockClient)(nil).UpdatePaddleJob), ctx, namespace, name, job, data)
 }
 
-// UpdatePytorchJob mocks base method.
-func (m *MockClient) UpdatePytorchJob(namespace, name string, job *v1.PyTorchJob, data []byte) error {
+// UpdatePyTorchJob mocks base method.
+func (m *MockClient) UpdatePyTorchJob(ctx context.Context, namespace, name string, job *v1.PyTorchJob, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePytorchJob", namespace, name, job, data)
+	ret := m.ctrl.Call(m, "UpdatePyTorchJob", ctx, namespace, name, job, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdatePytorchJob indicates an expected call of UpdatePytorchJob.
-func (mr *MockClientMockRecorder) UpdatePytorchJob(namespace, name, job, data interface{}) *gomock.Call {
+// UpdatePyTorchJob indicates an expected call of UpdatePyTorchJob.
+func (mr *MockClientMockRecorder) UpdatePyTorchJob(ctx, namespace, name, job, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePytorchJob", reflect.TypeOf((*MockClient)(nil).UpdatePytorchJob), namespace, name, job, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePyTorchJob", reflect.TypeOf((*MockClient)(nil).UpdatePyTorchJob), ctx, namespace, name, job, data)
 }
 
 // UpdateTFJob mocks base method.
-func (m *MockClient) UpdateTFJob(namespace, name string, job *v1.TFJob, data []byte) error {
+func (m *MockClient) UpdateTFJob(ctx context.Context, namespace, name string, job *v1.TFJob, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTFJob", namespace, name, job, data)
+	ret := m.ctrl.Call(m, "UpdateTFJob", ctx, namespace, name, job, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTFJob indicates an expected call of UpdateTFJob.
-func (mr *MockClientMockRecorder) UpdateTFJob(namespace, name, job, data interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) UpdateTFJob(ctx, namespace, name, job, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTFJob", reflect.TypeOf((*MockClient)(nil).UpdateTFJob), ctx, namespace, name, job, data)
+}
+
+// UpdateVolcanoQueue mocks base method.
+func (m *MockClient) UpdateVolcanoQueue(ctx context.Context, name string, data []byte) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVolcanoQueue", ctx, name, data)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVolcanoQueue indicates an expected call of UpdateVolcanoQueue.
+func (mr *MockClientMockRecorder) UpdateVolcanoQueue(ctx, name, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTFJob", reflect.TypeOf((*MockClient)(nil).UpdateTFJob), namespace, name, job, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVolcanoQueue", reflect.TypeOf((*MockClient)(nil).UpdateVolcanoQueue), ctx, name, data)
 }
 
 // UpdateXGBoostJob mocks base method.
-func (m *MockClient) UpdateXGBoostJob(namespace, name string, job *v1.XGBoostJob, data []byte) error {
+func (m *MockClient) UpdateXGBoostJob(ctx context.Context, namespace, name string, job *v1.XGBoostJob, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateXGBoostJob", namespace, name, job, data)
+	ret := m.ctrl.Call(m, "UpdateXGBoostJob", ctx, namespace, name, job, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateXGBoostJob indicates an expected call of UpdateXGBoostJob.
-func (mr *MockClientMockRecorder) UpdateXGBoostJob(namespace, name, job, data interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) UpdateXGBoostJob(ctx, namespace, name, job, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateXGBoostJob", reflect.TypeOf((*MockClient)(nil).UpdateXGBoostJob), ctx, namespace, name, job, data)
+}
+
+// WatchMPIJob mocks base method.
+func (m *MockClient) WatchMPIJob(ctx context.Context, namespace, name, resourceVersion string) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchMPIJob", ctx, namespace, name, resourceVersion)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchMPIJob indicates an expected call of WatchMPIJob.
+func (mr *MockClientMockRecorder) WatchMPIJob(ctx, namespace, name, resourceVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchMPIJob", reflect.TypeOf((*MockClient)(nil).WatchMPIJob), ctx, namespace, name, resourceVersion)
+}
+
+// WatchPaddleJob mocks base method.
+func (m *MockClient) WatchPaddleJob(ctx context.Context, namespace, name, resourceVersion string) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchPaddleJob", ctx, namespace, name, resourceVersion)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchPaddleJob indicates an expected call of WatchPaddleJob.
+func (mr *MockClientMockRecorder) WatchPaddleJob(ctx, namespace, name, resourceVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchPaddleJob", reflect.TypeOf((*MockClient)(nil).WatchPaddleJob), ctx, namespace, name, resourceVersion)
+}
+
+// WatchPyTorchJob mocks base method.
+func (m *MockClient) WatchPyTorchJob(ctx context.Context, namespace, name, resourceVersion string) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchPyTorchJob", ctx, namespace, name, resourceVersion)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchPyTorchJob indicates an expected call of WatchPyTorchJob.
+func (mr *MockClientMockRecorder) WatchPyTorchJob(ctx, namespace, name, resourceVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchPyTorchJob", reflect.TypeOf((*MockClient)(nil).WatchPyTorchJob), ctx, namespace, name, resourceVersion)
+}
+
+// WatchTFJob mocks base method.
+func (m *MockClient) WatchTFJob(ctx context.Context, namespace, name, resourceVersion string) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchTFJob", ctx, namespace, name, resourceVersion)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchTFJob indicates an expected call of WatchTFJob.
+func (mr *MockClientMockRecorder) WatchTFJob(ctx, namespace, name, resourceVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchTFJob", reflect.TypeOf((*MockClient)(nil).WatchTFJob), ctx, namespace, name, resourceVersion)
+}
+
+// WatchXGBoostJob mocks base method.
+func (m *MockClient) WatchXGBoostJob(ctx context.Context, namespace, name, resourceVersion string) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchXGBoostJob", ctx, namespace, name, resourceVersion)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchXGBoostJob indicates an expected call of WatchXGBoostJob.
+func (mr *MockClientMockRecorder) WatchXGBoostJob(ctx, namespace, name, resourceVersion interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateXGBoostJob", reflect.TypeOf((*MockClient)(nil).UpdateXGBoostJob), namespace, name, job, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchXGBoostJob", reflect.TypeOf((*MockClient)(nil).WatchXGBoostJob), ctx, namespace, name, resourceVersion)
 }
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+// The calls below go to the apiserver directly, as the informer cache only
+// serves jobs. Errors are wrapped so that callers can tell NotFound apart.
+
+// VolcanoQueueGroupVersion is the API version of Volcano queues.
+var VolcanoQueueGroupVersion = schema.GroupVersion{Group: "scheduling.volcano.sh", Version: "v1beta1"}
+
+func volcanoQueueRes() schema.GroupVersionResource {
+	return VolcanoQueueGroupVersion.WithResource("queues")
+}
+
+func priorityClassRes() schema.GroupVersionResource {
+	return schedulingv1.SchemeGroupVersion.WithResource("priorityclasses")
+}
+
+// CreateVolcanoQueue implements Client
+func (c *client) CreateVolcanoQueue(ctx context.Context, queue *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	queue.SetAPIVersion(VolcanoQueueGroupVersion.String())
+	queue.SetKind("Queue")
+	resp, err := c.dynamicClient.Resource(volcanoQueueRes()).Create(ctx, queue, metav1.CreateOptions{})
+	if err != nil {
+		log.Printf("[Error] Failed to create queue %s, with error: %v", queue.GetName(), err)
+		return nil, fmt.Errorf("Failed to create queue %s, with error: %w", queue.GetName(), err)
+	}
+	return resp, nil
+}
+
+// GetVolcanoQueue implements Client
+func (c *client) GetVolcanoQueue(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	resp, err := c.dynamicClient.Resource(volcanoQueueRes()).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get queue %s, with error: %w", name, err)
+	}
+	return resp, nil
+}
+
+// UpdateVolcanoQueue implements Client
+func (c *client) UpdateVolcanoQueue(ctx context.Context, name string, data []byte) (*unstructured.Unstructured, error) {
+	resp, err := c.dynamicClient.Resource(volcanoQueueRes()).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.Printf("[Error] Failed to update queue %s, with error: %v", name, err)
+		return nil, fmt.Errorf("Failed to update queue %s, with error: %w", name, err)
+	}
+	return resp, nil
+}
+
+// DeleteVolcanoQueue implements Client
+func (c *client) DeleteVolcanoQueue(ctx context.Context, name string) error {
+	return c.dynamicClient.Resource(volcanoQueueRes()).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// GetPriorityClass implements Client
+func (c *client) GetPriorityClass(ctx context.Context, name string) (*schedulingv1.PriorityClass, error) {
+	resp, err := c.dynamicClient.Resource(priorityClassRes()).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get PriorityClass %s, with error: %w", name, err)
+	}
+	var pc schedulingv1.PriorityClass
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(resp.UnstructuredContent(), &pc); err != nil {
+		return nil, fmt.Errorf("Failed to translate unstructed to PriorityClass, with error: %v", err)
+	}
+	return &pc, nil
+}
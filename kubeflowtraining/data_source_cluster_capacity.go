@@ -0,0 +1,238 @@
+package kubeflowtraining
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
+)
+
+func dataSourceKubeFlowClusterCapacity() *schema.Resource {
+	toleration := kubernetes.TolerationSchema()
+	toleration.Description = "Taints the pods of the job tolerate. Nodes with other NoSchedule or NoExecute taints are left out."
+
+	return &schema.Resource{
+		Read: dataSourceKubeFlowClusterCapacityRead,
+		Schema: map[string]*schema.Schema{
+			"label_selector": {
+				Type:        schema.TypeString,
+				Description: "Only count the nodes matching this label selector, e.g. a node pool.",
+				Optional:    true,
+				ValidateFunc: func(value interface{}, key string) (ws []string, es []error) {
+					if _, err := labels.Parse(value.(string)); err != nil {
+						es = append(es, fmt.Errorf("%s: %s", key, err))
+					}
+					return
+				},
+			},
+			"toleration": toleration,
+			"resources": {
+				Type:        schema.TypeList,
+				Description: "Resources to report, e.g. nvidia.com/gpu and rdma/hca. Defaults to cpu, memory and the extended resources of the nodes.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"node": {
+				Type:        schema.TypeList,
+				Description: "The schedulable nodes counted, ordered by name.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"labels": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"allocatable": capacitySchema("Resources of the node available to pods."),
+						"requested":   capacitySchema("Resources requested by the pods on the node that have not terminated."),
+						"free":        capacitySchema("Resources of the node not requested yet."),
+					},
+				},
+			},
+			"allocatable": capacitySchema("Resources of all the nodes available to pods."),
+			"requested":   capacitySchema("Resources requested by the pods on the nodes that have not terminated."),
+			"free":        capacitySchema("Resources of the nodes not requested yet."),
+			"max_free":    capacitySchema("The most of each resource free on a single node, which bounds what a single pod can request."),
+		},
+	}
+}
+
+func capacitySchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: description + " Quantities of whole units, e.g. of GPUs, are plain numbers.",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func dataSourceKubeFlowClusterCapacityRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	selector := resourceData.Get("label_selector").(string)
+	tolerations, err := kubernetes.ExpandTolerations(resourceData.Get("toleration").([]interface{}))
+	if err != nil {
+		return err
+	}
+	var names []corev1.ResourceName
+	for _, r := range resourceData.Get("resources").([]interface{}) {
+		names = append(names, corev1.ResourceName(r.(string)))
+	}
+
+	log.Printf("[INFO] Listing nodes matching %q", selector)
+	nodes, err := cli.ListNodes(ctx, selector)
+	if err != nil {
+		return err
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	log.Printf("[INFO] Listing the pods of all nodes")
+	pods, err := cli.ListNonTerminatedPods(ctx)
+	if err != nil {
+		return err
+	}
+	nodePods := map[string][]corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			nodePods[pod.Spec.NodeName] = append(nodePods[pod.Spec.NodeName], pod)
+		}
+	}
+
+	allocatable, requested, free, maxFree := corev1.ResourceList{}, corev1.ResourceList{}, corev1.ResourceList{}, corev1.ResourceList{}
+	var flattened []interface{}
+	for _, node := range nodes {
+		if !nodeSchedulable(node, tolerations) {
+			log.Printf("[DEBUG] Not counting node %s, which is cordoned or has taints not tolerated", node.Name)
+			continue
+		}
+		nodeRequested := corev1.ResourceList{}
+		for _, pod := range nodePods[node.Name] {
+			addResources(nodeRequested, podRequests(pod.Spec))
+		}
+
+		resources := names
+		if len(resources) == 0 {
+			resources = defaultCapacityResources(node.Status.Allocatable)
+		}
+		nodeAllocatable, nodeUsed, nodeFree := corev1.ResourceList{}, corev1.ResourceList{}, corev1.ResourceList{}
+		for _, r := range resources {
+			a := node.Status.Allocatable[r]
+			u := nodeRequested[r]
+			f := a.DeepCopy()
+			f.Sub(u)
+			if f.Sign() < 0 {
+				f = resource.Quantity{Format: a.Format}
+			}
+			nodeAllocatable[r], nodeUsed[r], nodeFree[r] = a, u, f
+		}
+		addResources(allocatable, nodeAllocatable)
+		addResources(requested, nodeUsed)
+		addResources(free, nodeFree)
+		maxResources(maxFree, nodeFree)
+
+		flattened = append(flattened, map[string]interface{}{
+			"name":        node.Name,
+			"labels":      utils.FlattenStringMap(node.Labels),
+			"allocatable": flattenCapacity(nodeAllocatable),
+			"requested":   flattenCapacity(nodeUsed),
+			"free":        flattenCapacity(nodeFree),
+		})
+	}
+
+	resourceData.SetId(capacityId(resourceData))
+	if err := resourceData.Set("node", flattened); err != nil {
+		return err
+	}
+	if err := resourceData.Set("allocatable", flattenCapacity(allocatable)); err != nil {
+		return err
+	}
+	if err := resourceData.Set("requested", flattenCapacity(requested)); err != nil {
+		return err
+	}
+	if err := resourceData.Set("free", flattenCapacity(free)); err != nil {
+		return err
+	}
+	return resourceData.Set("max_free", flattenCapacity(maxFree))
+}
+
+// nodeSchedulable reports whether pods with tolerations can be scheduled on
+// node: it is not cordoned and they tolerate its NoSchedule and NoExecute
+// taints.
+func nodeSchedulable(node corev1.Node, tolerations []corev1.Toleration) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// podRequests returns the effective requests of a pod: the sum over its
+// containers, or the largest init container if that is larger, plus its
+// overhead.
+func podRequests(spec corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, c := range spec.Containers {
+		addResources(requests, c.Resources.Requests)
+	}
+	for _, c := range spec.InitContainers {
+		maxResources(requests, c.Resources.Requests)
+	}
+	addResources(requests, spec.Overhead)
+	return requests
+}
+
+// defaultCapacityResources returns cpu, memory and the extended resources of
+// allocatable, such as nvidia.com/gpu.
+func defaultCapacityResources(allocatable corev1.ResourceList) []corev1.ResourceName {
+	names := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+	for _, r := range sortedResourceNames(allocatable) {
+		if strings.Contains(string(r), "/") && !strings.HasPrefix(string(r), corev1.ResourceDefaultNamespacePrefix) {
+			names = append(names, r)
+		}
+	}
+	return names
+}
+
+func flattenCapacity(l corev1.ResourceList) map[string]interface{} {
+	out := make(map[string]interface{}, len(l))
+	for r, q := range l {
+		out[string(r)] = q.String()
+	}
+	return out
+}
+
+func capacityId(resourceData *schema.ResourceData) string {
+	key := fmt.Sprintf("%s|%v|%v", resourceData.Get("label_selector"), resourceData.Get("toleration"), resourceData.Get("resources"))
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(key)))
+}
@@ -0,0 +1,89 @@
+package kubeflowtraining
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client/mock"
+)
+
+func TestClusterCapacityRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gpuTaint := corev1.Taint{Key: "nvidia.com/gpu", Value: "present", Effect: corev1.TaintEffectNoSchedule}
+	node := func(name string, gpus string, taints ...corev1.Taint) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"pool": "gpu"}},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:                    resource.MustParse("32"),
+				corev1.ResourceMemory:                 resource.MustParse("128Gi"),
+				corev1.ResourceName("nvidia.com/gpu"): resource.MustParse(gpus),
+				corev1.ResourceName("rdma/hca"):       resource.MustParse("1k"),
+			}},
+		}
+	}
+	pod := func(nodeName string, cpu string, gpus string) corev1.Pod {
+		return corev1.Pod{Spec: corev1.PodSpec{NodeName: nodeName, Containers: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU:                    resource.MustParse(cpu),
+				corev1.ResourceName("nvidia.com/gpu"): resource.MustParse(gpus),
+			}},
+		}}}}
+	}
+	cordoned := node("gpu-c", "8", gpuTaint)
+	cordoned.Spec.Unschedulable = true
+
+	cli := mock.NewMockClient(ctrl)
+	cli.EXPECT().ListNodes(gomock.Any(), "pool=gpu").Return([]corev1.Node{
+		node("gpu-b", "8", gpuTaint),
+		node("gpu-a", "8", gpuTaint),
+		cordoned,
+		node("gpu-d", "8", corev1.Taint{Key: "dedicated", Value: "infer", Effect: corev1.TaintEffectNoSchedule}),
+	}, nil)
+	cli.EXPECT().ListNonTerminatedPods(gomock.Any()).Return([]corev1.Pod{
+		pod("gpu-a", "8", "2"),
+		pod("gpu-a", "4", "4"),
+		// Pods of nodes not counted, or not scheduled yet, are left out.
+		pod("gpu-c", "8", "8"),
+		pod("", "8", "8"),
+	}, nil)
+
+	resourceData := schema.TestResourceDataRaw(t, dataSourceKubeFlowClusterCapacity().Schema, map[string]interface{}{
+		"label_selector": "pool=gpu",
+		"toleration": []interface{}{map[string]interface{}{
+			"key":      "nvidia.com/gpu",
+			"operator": "Exists",
+			"effect":   "NoSchedule",
+		}},
+		"resources": []interface{}{"nvidia.com/gpu", "cpu"},
+	})
+	if err := dataSourceKubeFlowClusterCapacityRead(resourceData, cli); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := resourceData.Get("node.#").(int); n != 2 {
+		t.Fatalf("expected 2 nodes, got %d", n)
+	}
+	for key, expected := range map[string]interface{}{
+		"node.0.name":      "gpu-a",
+		"node.0.free":      map[string]interface{}{"nvidia.com/gpu": "2", "cpu": "20"},
+		"node.1.name":      "gpu-b",
+		"allocatable":      map[string]interface{}{"nvidia.com/gpu": "16", "cpu": "64"},
+		"requested":        map[string]interface{}{"nvidia.com/gpu": "6", "cpu": "12"},
+		"free":             map[string]interface{}{"nvidia.com/gpu": "10", "cpu": "52"},
+		"max_free":         map[string]interface{}{"nvidia.com/gpu": "8", "cpu": "32"},
+		"node.1.requested": map[string]interface{}{"nvidia.com/gpu": "0", "cpu": "0"},
+	} {
+		if actual := resourceData.Get(key); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: expected %v, got %v", key, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package kubeflowtraining
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+func dataSourceKubeFlowPipelinesExperiment() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubeFlowPipelinesExperimentRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Description:  "ID of the experiment.",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the experiment.",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the experiment.",
+				Computed:    true,
+			},
+			"storage_state": {
+				Type:        schema.TypeString,
+				Description: "Storage state of the experiment, STORAGESTATE_AVAILABLE or STORAGESTATE_ARCHIVED.",
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "The date and time of creation, formatted with RFC3339.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubeFlowPipelinesExperimentRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	var experiment *pipelines.Experiment
+	var err error
+	if id, ok := resourceData.GetOk("id"); ok {
+		log.Printf("[INFO] Reading experiment %s", id)
+		experiment, err = cli.GetExperiment(ctx, id.(string))
+	} else {
+		name := resourceData.Get("name").(string)
+		log.Printf("[INFO] Looking up experiment %s", name)
+		experiment, err = findExperiment(ctx, cli, name, func(*pipelines.Experiment) bool { return true })
+		if err == nil && experiment == nil {
+			err = fmt.Errorf("no experiment is called %s", name)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read experiment: %s", err)
+	}
+
+	resourceData.SetId(experiment.ID)
+	if err := resourceData.Set("name", experiment.Name); err != nil {
+		return err
+	}
+	if err := resourceData.Set("description", experiment.Description); err != nil {
+		return err
+	}
+	if err := resourceData.Set("storage_state", experiment.StorageState); err != nil {
+		return err
+	}
+	if experiment.CreatedAt != nil {
+		return resourceData.Set("created_at", experiment.CreatedAt.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package kubeflowtraining
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
+)
+
+func dataSourceKubeFlowJobLogs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubeFlowJobLogsRead,
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:         schema.TypeString,
+				Description:  "Kind of the job.",
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(jobKindNames(), false),
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the job.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the job.",
+				Required:     true,
+				ValidateFunc: utils.ValidateName,
+			},
+			"replica_type": {
+				Type:        schema.TypeString,
+				Description: "Replica type of the pod to read the logs of. Defaults to the master, chief or launcher of the job, or its first worker when it has none.",
+				Optional:    true,
+			},
+			"replica_index": {
+				Type:         schema.TypeInt,
+				Description:  "Index of the pod within its replica type.",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"container": {
+				Type:        schema.TypeString,
+				Description: "Container to read the logs of. Defaults to the training container of the job kind, or the only container of the pod.",
+				Optional:    true,
+			},
+			"tail_lines": {
+				Type:         schema.TypeInt,
+				Description:  "Only return this many lines from the end of the logs.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"since_seconds": {
+				Type:         schema.TypeInt,
+				Description:  "Only return logs newer than this many seconds.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"previous": {
+				Type:        schema.TypeBool,
+				Description: "Return the logs of the previous, terminated run of the container.",
+				Optional:    true,
+				Default:     false,
+			},
+			"pod_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the pod the logs were read from.",
+				Computed:    true,
+			},
+			"logs": {
+				Type:        schema.TypeString,
+				Description: "The logs.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubeFlowJobLogsRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	kindName := resourceData.Get("kind").(string)
+	namespace := resourceData.Get("namespace").(string)
+	name := resourceData.Get("name").(string)
+
+	pod, err := findJobPod(ctx, cli, kindName, namespace, name, resourceData.Get("replica_type").(string), resourceData.Get("replica_index").(int))
+	if err != nil {
+		return err
+	}
+
+	options := &corev1.PodLogOptions{
+		Container: jobPodContainer(pod, kindName, resourceData.Get("container").(string)),
+		Previous:  resourceData.Get("previous").(bool),
+	}
+	if v, ok := resourceData.GetOk("tail_lines"); ok {
+		options.TailLines = utils.PtrToInt64(int64(v.(int)))
+	}
+	if v, ok := resourceData.GetOk("since_seconds"); ok {
+		options.SinceSeconds = utils.PtrToInt64(int64(v.(int)))
+	}
+
+	log.Printf("[INFO] Reading logs of container %s of pod %s", options.Container, pod.Name)
+	logs, err := cli.GetPodLogs(ctx, namespace, pod.Name, options)
+	if err != nil {
+		return err
+	}
+
+	resourceData.SetId(namespace + "/" + pod.Name + "/" + options.Container)
+	if err := resourceData.Set("pod_name", pod.Name); err != nil {
+		return err
+	}
+	return resourceData.Set("logs", logs)
+}
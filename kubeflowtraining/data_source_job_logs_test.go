@@ -0,0 +1,64 @@
+package kubeflowtraining
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client/mock"
+)
+
+func testJobPod(name string, replicaType string, index string, containers ...string) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				commonv1.JobNameLabel:      "test",
+				commonv1.ReplicaTypeLabel:  replicaType,
+				commonv1.ReplicaIndexLabel: index,
+			},
+		},
+	}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: c})
+	}
+	return pod
+}
+
+func TestDataSourceJobLogsRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cli := mock.NewMockClient(ctrl)
+	cli.EXPECT().GetPyTorchJob(gomock.Any(), "default", "test").Return(testPyTorchJob("1", commonv1.JobSucceeded), nil)
+	cli.EXPECT().ListPods(gomock.Any(), "default", commonv1.JobNameLabel+"=test").Return([]corev1.Pod{
+		testJobPod("test-worker-0", "worker", "0", "pytorch"),
+		testJobPod("test-master-0", "master", "0", "sidecar", "pytorch"),
+	}, nil)
+
+	tailLines := int64(10)
+	cli.EXPECT().GetPodLogs(gomock.Any(), "default", "test-master-0", &corev1.PodLogOptions{
+		Container: "pytorch",
+		TailLines: &tailLines,
+	}).Return("loss=0.1\n", nil)
+
+	resourceData := schema.TestResourceDataRaw(t, dataSourceKubeFlowJobLogs().Schema, map[string]interface{}{
+		"kind":       "PyTorchJob",
+		"name":       "test",
+		"tail_lines": 10,
+	})
+	if err := dataSourceKubeFlowJobLogsRead(resourceData, cli); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v := resourceData.Get("pod_name").(string); v != "test-master-0" {
+		t.Fatalf("expected logs of test-master-0, got %q", v)
+	}
+	if v := resourceData.Get("logs").(string); v != "loss=0.1\n" {
+		t.Fatalf("unexpected logs %q", v)
+	}
+}
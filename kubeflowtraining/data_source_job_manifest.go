@@ -0,0 +1,217 @@
+package kubeflowtraining
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	mpiv2beta1 "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/mpi_job"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/pytorch_job"
+)
+
+// manifestJob is a job block of the kubeflow_job_manifest data source: the
+// schema of a job resource and the function expanding it into a typed job
+// with its type meta set. Only the kinds whose spec is fully expanded are
+// listed, the others would render an empty spec.
+type manifestJob struct {
+	fields func() map[string]*schema.Schema
+	expand func(l []interface{}) (interface{}, error)
+}
+
+var manifestJobs = map[string]manifestJob{
+	"pytorch_job": {
+		fields: pytorch_job.PyTorchJobFields,
+		expand: func(l []interface{}) (interface{}, error) {
+			job, err := pytorch_job.ExpandPyTorchJob(l)
+			if err != nil {
+				return nil, err
+			}
+			kubernetes.ExpandKueue(manifestKueue(l), &job.ObjectMeta)
+			job.TypeMeta = metav1.TypeMeta{Kind: kubeflowv1.PytorchJobKind, APIVersion: kubeflowv1.GroupVersion.String()}
+			return job, nil
+		},
+	},
+	"mpi_job": {
+		fields: mpi_job.MPIJobFields,
+		expand: func(l []interface{}) (interface{}, error) {
+			job, err := mpi_job.ExpandMPIJob(l)
+			if err != nil {
+				return nil, err
+			}
+			kubernetes.ExpandKueue(manifestKueue(l), &job.ObjectMeta)
+			job.TypeMeta = metav1.TypeMeta{Kind: mpiv2beta1.Kind, APIVersion: mpiv2beta1.SchemeGroupVersion.String()}
+			return job, nil
+		},
+	},
+}
+
+// manifestKueue returns the kueue block of the job block l.
+func manifestKueue(l []interface{}) []interface{} {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	kueue, _ := l[0].(map[string]interface{})["kueue"].([]interface{})
+	return kueue
+}
+
+func manifestJobNames() []string {
+	names := make([]string, 0, len(manifestJobs))
+	for name := range manifestJobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func dataSourceKubeFlowJobManifest() *schema.Resource {
+	fields := map[string]*schema.Schema{
+		"format": {
+			Type:         schema.TypeString,
+			Description:  "Format of the manifest, yaml or json.",
+			Optional:     true,
+			Default:      "yaml",
+			ValidateFunc: validation.StringInSlice([]string{"yaml", "json"}, false),
+		},
+		"manifest": {
+			Type:        schema.TypeString,
+			Description: "The job rendered as a manifest for kubectl or a pipeline launcher component. It is sensitive, as it holds the values of sensitive environment variables.",
+			Computed:    true,
+			Sensitive:   true,
+		},
+	}
+	for name, job := range manifestJobs {
+		jobFields := job.fields()
+		fields[name] = &schema.Schema{
+			Type:         schema.TypeList,
+			Description:  "The job to render, configured like the kubeflow_" + name + " resource.",
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: manifestJobNames(),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"metadata": jobFields["metadata"],
+					"spec":     jobFields["spec"],
+					"kueue":    jobFields["kueue"],
+				},
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Renders a job, configured like a job resource, to a manifest. Only PyTorchJobs and MPIJobs can be rendered, as the specs of the other kinds are not expanded yet.",
+		Read:        dataSourceKubeFlowJobManifestRead,
+		Schema:      fields,
+	}
+}
+
+func dataSourceKubeFlowJobManifestRead(resourceData *schema.ResourceData, meta interface{}) error {
+	for _, name := range manifestJobNames() {
+		v, ok := resourceData.GetOk(name)
+		if !ok {
+			continue
+		}
+		job, err := manifestJobs[name].expand(v.([]interface{}))
+		if err != nil {
+			return err
+		}
+		manifest, err := renderJobManifest(job, resourceData.Get("format").(string))
+		if err != nil {
+			return fmt.Errorf("unable to render %s: %s", name, err)
+		}
+		if err := resourceData.Set("manifest", manifest); err != nil {
+			return err
+		}
+		resourceData.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(manifest))))
+		return nil
+	}
+	return fmt.Errorf("one of %v must be set", manifestJobNames())
+}
+
+// renderJobManifest renders job without its status and the fields the API
+// server sets, with sorted keys so that equal jobs render equally.
+func renderJobManifest(job interface{}, format string) (string, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return "", err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return "", err
+	}
+	delete(obj, "status")
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		for _, k := range []string{"creationTimestamp", "resourceVersion", "uid", "generation", "selfLink"} {
+			delete(metadata, k)
+		}
+	}
+	if spec, ok := obj["spec"].(map[string]interface{}); ok {
+		for k, replicaSpecs := range spec {
+			if !strings.HasSuffix(k, "ReplicaSpecs") {
+				continue
+			}
+			replicas, _ := replicaSpecs.(map[string]interface{})
+			for _, replica := range replicas {
+				replica, _ := replica.(map[string]interface{})
+				template, _ := replica["template"].(map[string]interface{})
+				if metadata, ok := template["metadata"].(map[string]interface{}); ok {
+					pruneInternalAnnotations(metadata)
+				}
+			}
+		}
+	}
+	pruneNulls(obj)
+
+	if format == "json" {
+		data, err = json.MarshalIndent(obj, "", "  ")
+	} else {
+		data, err = yaml.Marshal(obj)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// pruneInternalAnnotations removes from the metadata of a pod template the
+// annotations that only record for the provider how the template was
+// expanded.
+func pruneInternalAnnotations(metadata map[string]interface{}) {
+	annotations, ok := metadata["annotations"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(annotations, kubernetes.SensitiveEnvAnnotation)
+	delete(annotations, kubernetes.AcceleratorAnnotation)
+	if len(annotations) == 0 {
+		delete(metadata, "annotations")
+	}
+}
+
+// pruneNulls removes the null fields of obj, e.g. the unset timestamps of
+// pod templates.
+func pruneNulls(obj interface{}) {
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if value == nil {
+				delete(v, k)
+				continue
+			}
+			pruneNulls(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			pruneNulls(value)
+		}
+	}
+}
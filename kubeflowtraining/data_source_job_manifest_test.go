@@ -0,0 +1,58 @@
+package kubeflowtraining
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourceJobManifest(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, dataSourceKubeFlowJobManifest().Schema, map[string]interface{}{
+		"pytorch_job": []interface{}{map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{
+				"name": "test",
+			}},
+			"kueue": []interface{}{map[string]interface{}{
+				"queue_name": "research",
+			}},
+			"spec": []interface{}{map[string]interface{}{
+				"pytorch_replica_specs": []interface{}{map[string]interface{}{
+					"master": []interface{}{map[string]interface{}{
+						"accelerator": []interface{}{map[string]interface{}{
+							"type":  "nvidia",
+							"count": 1,
+						}},
+						"template": []interface{}{map[string]interface{}{
+							"spec": []interface{}{map[string]interface{}{
+								"container": []interface{}{map[string]interface{}{
+									"name":  "pytorch",
+									"image": "pytorch:latest",
+									"env": []interface{}{map[string]interface{}{
+										"name":            "API_KEY",
+										"sensitive_value": "secret",
+									}},
+								}},
+							}},
+						}},
+					}},
+				}},
+			}},
+		}},
+	})
+	if err := dataSourceKubeFlowJobManifestRead(resourceData, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	manifest := resourceData.Get("manifest").(string)
+	for _, s := range []string{"apiVersion: kubeflow.org/v1\n", "kind: PyTorchJob\n", "  name: test\n", "kueue.x-k8s.io/queue-name: research\n", "image: pytorch:latest\n", "value: secret\n", "nvidia.com/gpu: \"1\"\n"} {
+		if !strings.Contains(manifest, s) {
+			t.Fatalf("expected the manifest to contain %q, got:\n%s", s, manifest)
+		}
+	}
+	for _, s := range []string{"status", "null", "creationTimestamp", "annotations", "terraform.kubeflow.org"} {
+		if strings.Contains(manifest, s) {
+			t.Fatalf("expected the manifest not to contain %q, got:\n%s", s, manifest)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
+)
+
+func dataSourceKubeFlowJobPods() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubeFlowJobPodsRead,
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:         schema.TypeString,
+				Description:  "Kind of the job.",
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(jobKindNames(), false),
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the job.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the job.",
+				Required:     true,
+				ValidateFunc: utils.ValidateName,
+			},
+			"replica_type": {
+				Type:        schema.TypeString,
+				Description: "Only return the pods of this replica type, e.g. Master or Worker.",
+				Optional:    true,
+			},
+			"pods": {
+				Type:        schema.TypeList,
+				Description: "Pods of the job, ordered by replica type and index.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"replica_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"replica_index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"node_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pod_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"phase": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"restart_count": {
+							Type:        schema.TypeInt,
+							Description: "Restarts of all containers of the pod.",
+							Computed:    true,
+						},
+						"container_status": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"ready": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"restart_count": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"terminated":      containerTerminationSchema("Termination of the container, when it has terminated."),
+									"last_terminated": containerTerminationSchema("Termination of the previous run of the container, when it was restarted."),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func containerTerminationSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: description,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"exit_code": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"reason": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"message": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"finished_at": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubeFlowJobPodsRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	kindName := resourceData.Get("kind").(string)
+	namespace := resourceData.Get("namespace").(string)
+	name := resourceData.Get("name").(string)
+	replicaType := resourceData.Get("replica_type").(string)
+
+	pods, err := listJobPods(ctx, cli, kindName, namespace, name, replicaType)
+	if err != nil {
+		return err
+	}
+
+	resourceData.SetId(namespace + "/" + name)
+	return resourceData.Set("pods", flattenJobPods(pods))
+}
+
+// listJobPods returns the pods of a job, optionally only those of
+// replicaType, ordered by replica type and index.
+func listJobPods(ctx context.Context, cli client.Client, kindName string, namespace string, name string, replicaType string) ([]corev1.Pod, error) {
+	kind := jobKinds[kindName]
+	obj, err := kind.get(ctx, cli, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s %s/%s: %s", kindName, namespace, name, err)
+	}
+
+	seen := map[string]bool{}
+	var pods []corev1.Pod
+	for _, selector := range jobPodSelectors(name, kind.replicaStatuses(obj)) {
+		log.Printf("[DEBUG] Listing pods of %s %s with selector %q", kindName, name, selector)
+		list, err := cli.ListPods(ctx, namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range list {
+			if seen[pod.Name] {
+				continue
+			}
+			seen[pod.Name] = true
+			if replicaType != "" && !strings.EqualFold(podReplicaType(pod), replicaType) {
+				continue
+			}
+			pods = append(pods, pod)
+		}
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		ti, tj := podReplicaType(pods[i]), podReplicaType(pods[j])
+		if ti != tj {
+			return ti < tj
+		}
+		return podReplicaIndex(pods[i]) < podReplicaIndex(pods[j])
+	})
+	return pods, nil
+}
+
+// findJobPod returns the pod of a job with the given replica type and index.
+// Without a replica type, the first primary replica type of the kind that has
+// such a pod is used.
+func findJobPod(ctx context.Context, cli client.Client, kindName string, namespace string, name string, replicaType string, replicaIndex int) (*corev1.Pod, error) {
+	pods, err := listJobPods(ctx, cli, kindName, namespace, name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	replicaTypes := jobKinds[kindName].primaryReplicaTypes
+	if replicaType != "" {
+		replicaTypes = []string{replicaType}
+	}
+	for _, t := range replicaTypes {
+		for i := range pods {
+			if strings.EqualFold(podReplicaType(pods[i]), t) && podReplicaIndex(pods[i]) == replicaIndex {
+				return &pods[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%s %s/%s has no %s pod with index %d", kindName, namespace, name, strings.Join(replicaTypes, " or "), replicaIndex)
+}
+
+// jobPodContainer returns the container of pod to read: the named one, the
+// kind's default container, or the only container of the pod.
+func jobPodContainer(pod *corev1.Pod, kindName string, container string) string {
+	if container != "" {
+		return container
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == jobKinds[kindName].defaultContainer {
+			return c.Name
+		}
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
+
+// jobPodSelectors returns the label selectors of the job's pods: the ones the
+// operator publishes per replica type in the job status, or the job name
+// label when the status has none yet.
+func jobPodSelectors(name string, replicaStatuses map[commonv1.ReplicaType]*commonv1.ReplicaStatus) []string {
+	var selectors []string
+	for _, rs := range replicaStatuses {
+		if rs != nil && rs.Selector != "" {
+			selectors = append(selectors, rs.Selector)
+		}
+	}
+	if len(selectors) == 0 {
+		return []string{labels.SelectorFromSet(labels.Set{commonv1.JobNameLabel: name}).String()}
+	}
+	sort.Strings(selectors)
+	return selectors
+}
+
+// podReplicaType returns the replica type a pod was created for. The
+// mpi-operator labels its pods with a role instead of a replica type.
+func podReplicaType(pod corev1.Pod) string {
+	if t, ok := pod.Labels[commonv1.ReplicaTypeLabel]; ok {
+		return t
+	}
+	return pod.Labels[commonv1.JobRoleLabel]
+}
+
+func podReplicaIndex(pod corev1.Pod) int {
+	index, err := strconv.Atoi(pod.Labels[commonv1.ReplicaIndexLabel])
+	if err != nil {
+		return 0
+	}
+	return index
+}
+
+func flattenJobPods(pods []corev1.Pod) []interface{} {
+	result := make([]interface{}, 0, len(pods))
+	for _, pod := range pods {
+		var restarts int32
+		statuses := make([]interface{}, 0, len(pod.Status.ContainerStatuses))
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+			statuses = append(statuses, map[string]interface{}{
+				"name":            cs.Name,
+				"ready":           cs.Ready,
+				"restart_count":   int(cs.RestartCount),
+				"terminated":      flattenContainerTermination(cs.State.Terminated),
+				"last_terminated": flattenContainerTermination(cs.LastTerminationState.Terminated),
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":             pod.Name,
+			"replica_type":     podReplicaType(pod),
+			"replica_index":    podReplicaIndex(pod),
+			"node_name":        pod.Spec.NodeName,
+			"pod_ip":           pod.Status.PodIP,
+			"phase":            string(pod.Status.Phase),
+			"restart_count":    int(restarts),
+			"container_status": statuses,
+		})
+	}
+	return result
+}
+
+func flattenContainerTermination(in *corev1.ContainerStateTerminated) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"exit_code":   int(in.ExitCode),
+		"reason":      in.Reason,
+		"message":     in.Message,
+		"finished_at": in.FinishedAt.Format(time.RFC3339),
+	}}
+}
@@ -0,0 +1,147 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client/mock"
+)
+
+func TestJobPodSelectors(t *testing.T) {
+	cases := map[string]struct {
+		in       map[commonv1.ReplicaType]*commonv1.ReplicaStatus
+		expected []string
+	}{
+		"no status yet": {
+			expected: []string{commonv1.JobNameLabel + "=test"},
+		},
+		"no selectors published": {
+			in:       map[commonv1.ReplicaType]*commonv1.ReplicaStatus{"Master": {Active: 1}, "Worker": nil},
+			expected: []string{commonv1.JobNameLabel + "=test"},
+		},
+		"selectors of the status, sorted": {
+			in: map[commonv1.ReplicaType]*commonv1.ReplicaStatus{
+				"Worker": {Selector: "training.kubeflow.org/job-name=test,training.kubeflow.org/replica-type=worker"},
+				"Master": {Selector: "training.kubeflow.org/job-name=test,training.kubeflow.org/replica-type=master"},
+			},
+			expected: []string{
+				"training.kubeflow.org/job-name=test,training.kubeflow.org/replica-type=master",
+				"training.kubeflow.org/job-name=test,training.kubeflow.org/replica-type=worker",
+			},
+		},
+	}
+	for name, tc := range cases {
+		if actual := jobPodSelectors("test", tc.in); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", name, tc.expected, actual)
+		}
+	}
+}
+
+func TestListJobPods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const masterSelector = "training.kubeflow.org/job-name=test,training.kubeflow.org/replica-type=master"
+	const workerSelector = "training.kubeflow.org/job-name=test,training.kubeflow.org/replica-type=worker"
+	ptj := testPyTorchJob("1")
+	ptj.Status.ReplicaStatuses = map[commonv1.ReplicaType]*commonv1.ReplicaStatus{
+		kubeflowv1.PyTorchJobReplicaTypeMaster: {Selector: masterSelector},
+		kubeflowv1.PyTorchJobReplicaTypeWorker: {Selector: workerSelector},
+	}
+	master := testJobPod("test-master-0", "master", "0", "pytorch")
+	workers := []corev1.Pod{
+		testJobPod("test-worker-10", "worker", "10", "pytorch"),
+		testJobPod("test-worker-2", "worker", "2", "pytorch"),
+	}
+
+	cli := mock.NewMockClient(ctrl)
+	cli.EXPECT().GetPyTorchJob(gomock.Any(), "default", "test").Return(ptj, nil).Times(2)
+	// A pod matched by several selectors is only returned once.
+	cli.EXPECT().ListPods(gomock.Any(), "default", masterSelector).Return([]corev1.Pod{master, workers[0]}, nil).Times(2)
+	cli.EXPECT().ListPods(gomock.Any(), "default", workerSelector).Return(workers, nil).Times(2)
+
+	names := func(pods []corev1.Pod) []string {
+		var out []string
+		for _, pod := range pods {
+			out = append(out, pod.Name)
+		}
+		return out
+	}
+	for replicaType, expected := range map[string][]string{
+		"":       {"test-master-0", "test-worker-2", "test-worker-10"},
+		"Worker": {"test-worker-2", "test-worker-10"},
+	} {
+		pods, err := listJobPods(context.Background(), cli, "PyTorchJob", "default", "test", replicaType)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if actual := names(pods); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("replica type %q: expected pods %v, got %v", replicaType, expected, actual)
+		}
+	}
+}
+
+func TestFlattenJobPods(t *testing.T) {
+	finishedAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	pod := testJobPod("test-master-0", "master", "0", "pytorch", "sidecar")
+	pod.Spec.NodeName = "gpu-a"
+	pod.Status = corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		PodIP: "10.0.0.7",
+		ContainerStatuses: []corev1.ContainerStatus{
+			{
+				Name:         "pytorch",
+				Ready:        true,
+				RestartCount: 2,
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode:   137,
+					Reason:     "OOMKilled",
+					FinishedAt: metav1.NewTime(finishedAt),
+				}},
+			},
+			{Name: "sidecar", Ready: true, RestartCount: 1},
+		},
+	}
+
+	expected := []interface{}{map[string]interface{}{
+		"name":          "test-master-0",
+		"replica_type":  "master",
+		"replica_index": 0,
+		"node_name":     "gpu-a",
+		"pod_ip":        "10.0.0.7",
+		"phase":         "Running",
+		"restart_count": 3,
+		"container_status": []interface{}{
+			map[string]interface{}{
+				"name":          "pytorch",
+				"ready":         true,
+				"restart_count": 2,
+				"terminated":    []interface{}{},
+				"last_terminated": []interface{}{map[string]interface{}{
+					"exit_code":   137,
+					"reason":      "OOMKilled",
+					"message":     "",
+					"finished_at": "2023-05-01T12:00:00Z",
+				}},
+			},
+			map[string]interface{}{
+				"name":            "sidecar",
+				"ready":           true,
+				"restart_count":   1,
+				"terminated":      []interface{}{},
+				"last_terminated": []interface{}{},
+			},
+		},
+	}}
+	if actual := flattenJobPods([]corev1.Pod{pod}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
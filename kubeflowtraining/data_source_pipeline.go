@@ -0,0 +1,247 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+// pipelineSpecAnnotation holds the inputs of a compiled pipeline.
+const pipelineSpecAnnotation = "pipelines.kubeflow.org/pipeline_spec"
+
+func dataSourceKubeFlowPipelinesPipeline() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubeFlowPipelinesPipelineRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Description:  "ID of the pipeline.",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the pipeline.",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the pipeline.",
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "The date and time of creation, formatted with RFC3339.",
+				Computed:    true,
+			},
+			"version_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the latest version of the pipeline.",
+				Computed:    true,
+			},
+			"parameters": {
+				Type:        schema.TypeList,
+				Description: "Parameters of the latest version of the pipeline.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"optional": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubeFlowPipelinesPipelineRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	var pipeline *pipelines.Pipeline
+	var err error
+	if id, ok := resourceData.GetOk("id"); ok {
+		log.Printf("[INFO] Reading pipeline %s", id)
+		pipeline, err = cli.GetPipeline(ctx, id.(string))
+	} else {
+		name := resourceData.Get("name").(string)
+		log.Printf("[INFO] Looking up pipeline %s", name)
+		pipeline, err = findPipeline(ctx, cli, name)
+		if err == nil && pipeline == nil {
+			err = fmt.Errorf("no pipeline is called %s", name)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read pipeline: %s", err)
+	}
+
+	resourceData.SetId(pipeline.ID)
+	if err := resourceData.Set("name", pipeline.Name); err != nil {
+		return err
+	}
+	if err := resourceData.Set("description", pipeline.Description); err != nil {
+		return err
+	}
+	if pipeline.CreatedAt != nil {
+		if err := resourceData.Set("created_at", pipeline.CreatedAt.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+
+	version, err := latestPipelineVersion(ctx, cli, pipeline)
+	if err != nil {
+		return err
+	}
+	if version == nil {
+		if err := resourceData.Set("version_id", ""); err != nil {
+			return err
+		}
+		return resourceData.Set("parameters", []interface{}{})
+	}
+	if err := resourceData.Set("version_id", version.ID); err != nil {
+		return err
+	}
+
+	template, err := cli.GetPipelineVersionTemplate(ctx, version.ID)
+	if err != nil {
+		return fmt.Errorf("unable to read the template of pipeline version %s: %s", version.ID, err)
+	}
+	parameters, err := flattenPipelineParameters(template, version.Parameters)
+	if err != nil {
+		return fmt.Errorf("unable to parse the template of pipeline version %s: %s", version.ID, err)
+	}
+	return resourceData.Set("parameters", parameters)
+}
+
+// findPipeline pages through the pipelines called name and returns the first
+// one, or nil if there is none.
+func findPipeline(ctx context.Context, cli pipelines.Client, name string) (*pipelines.Pipeline, error) {
+	opts := pipelines.ListOptions{Filter: nameFilter(name)}
+	for {
+		resp, err := cli.ListPipelines(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list pipelines: %s", err)
+		}
+		for i := range resp.Pipelines {
+			if resp.Pipelines[i].Name == name {
+				return &resp.Pipelines[i], nil
+			}
+		}
+		if resp.NextPageToken == "" {
+			return nil, nil
+		}
+		opts.PageToken = resp.NextPageToken
+	}
+}
+
+// latestPipelineVersion returns the version of the pipeline created last,
+// falling back to its default version.
+func latestPipelineVersion(ctx context.Context, cli pipelines.Client, pipeline *pipelines.Pipeline) (*pipelines.PipelineVersion, error) {
+	resp, err := cli.ListPipelineVersions(ctx, pipeline.ID, pipelines.ListOptions{
+		PageSize: 1,
+		SortBy:   "created_at desc",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the versions of pipeline %s: %s", pipeline.ID, err)
+	}
+	if len(resp.Versions) > 0 {
+		return &resp.Versions[0], nil
+	}
+	return pipeline.DefaultVersion, nil
+}
+
+// pipelineInput is an input of the pipeline spec annotation. Its type is
+// either a name or an object.
+type pipelineInput struct {
+	Name        string          `json:"name"`
+	Type        json.RawMessage `json:"type"`
+	Default     *string         `json:"default"`
+	Optional    bool            `json:"optional"`
+	Description string          `json:"description"`
+}
+
+// flattenPipelineParameters returns the parameters declared by the pipeline
+// spec annotation of a compiled workflow. Templates without the annotation
+// fall back to the parameters the API reports, which have no types.
+func flattenPipelineParameters(template string, fallback []pipelines.Parameter) ([]interface{}, error) {
+	var workflow struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	if err := yaml.Unmarshal([]byte(template), &workflow); err != nil {
+		return nil, err
+	}
+
+	parameters := []interface{}{}
+	spec, ok := workflow.Metadata.Annotations[pipelineSpecAnnotation]
+	if !ok {
+		for _, p := range fallback {
+			parameters = append(parameters, map[string]interface{}{
+				"name":    p.Name,
+				"default": p.Value,
+			})
+		}
+		return parameters, nil
+	}
+
+	var pipelineSpec struct {
+		Inputs []pipelineInput `json:"inputs"`
+	}
+	if err := json.Unmarshal([]byte(spec), &pipelineSpec); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %s", pipelineSpecAnnotation, err)
+	}
+	for _, in := range pipelineSpec.Inputs {
+		parameter := map[string]interface{}{
+			"name":        in.Name,
+			"type":        pipelineInputType(in.Type),
+			"optional":    in.Optional || in.Default != nil,
+			"description": in.Description,
+		}
+		if in.Default != nil {
+			parameter["default"] = *in.Default
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters, nil
+}
+
+func pipelineInputType(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		return name
+	}
+	return string(raw)
+}
@@ -0,0 +1,74 @@
+package kubeflowtraining
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+func TestDataSourcePipelineByName(t *testing.T) {
+	template := `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  annotations:
+    pipelines.kubeflow.org/pipeline_spec: '{"inputs": [{"name": "epochs", "type": "Integer", "default": "3"}, {"name": "data", "type": {"GCSPath": {}}}], "name": "example"}'
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/apis/v1beta1/pipelines":
+			if query.Get("page_token") == "" {
+				io.WriteString(w, `{"pipelines": [{"id": "p0", "name": "example-old"}], "next_page_token": "next"}`)
+				return
+			}
+			io.WriteString(w, `{"pipelines": [{"id": "p1", "name": "example", "description": "Description"}]}`)
+		case "/apis/v1beta1/pipeline_versions":
+			if query.Get("resource_key.id") != "p1" || query.Get("sort_by") != "created_at desc" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			io.WriteString(w, `{"versions": [{"id": "v2", "name": "v0.0.2"}]}`)
+		case "/apis/v1beta1/pipeline_versions/v2/templates":
+			json.NewEncoder(w).Encode(map[string]string{"template": template})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta := &providerMeta{pipelines: pipelines.NewClient(server.URL, server.Client())}
+	resourceData := schema.TestResourceDataRaw(t, dataSourceKubeFlowPipelinesPipeline().Schema, map[string]interface{}{
+		"name": "example",
+	})
+	if err := dataSourceKubeFlowPipelinesPipelineRead(resourceData, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resourceData.Id() != "p1" {
+		t.Fatalf("expected id p1, got %q", resourceData.Id())
+	}
+	if v := resourceData.Get("version_id").(string); v != "v2" {
+		t.Fatalf("expected version_id v2, got %q", v)
+	}
+	expected := []map[string]interface{}{
+		{"name": "epochs", "type": "Integer", "default": "3", "optional": true},
+		{"name": "data", "type": `{"GCSPath": {}}`, "default": "", "optional": false},
+	}
+	parameters := resourceData.Get("parameters").([]interface{})
+	if len(parameters) != len(expected) {
+		t.Fatalf("expected parameters %v, got %v", expected, parameters)
+	}
+	for i := range expected {
+		for k, v := range expected[i] {
+			if parameters[i].(map[string]interface{})[k] != v {
+				t.Fatalf("expected parameters %v, got %v", expected, parameters)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+)
+
+// adoptExistingJob handles a create of planned that failed with createErr.
+// When the job already exists and adopt_existing is set, the live job is
+// returned if it matches planned, so that it can be taken into state;
+// otherwise the error names the fields that differ.
+func adoptExistingJob(ctx context.Context, cli client.Client, resourceData *schema.ResourceData, kindName string, namespace string, name string, planned interface{}, createErr error) (interface{}, error) {
+	if !errors.IsAlreadyExists(createErr) || !resourceData.Get("adopt_existing").(bool) {
+		return nil, createErr
+	}
+
+	live, err := jobKinds[kindName].get(ctx, cli, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read existing %s %s/%s to adopt it: %s", kindName, namespace, name, err)
+	}
+	diff, err := jobDiff(planned, live)
+	if err != nil {
+		return nil, err
+	}
+	if len(diff) > 0 {
+		return nil, fmt.Errorf("%s %s/%s already exists and differs from the configuration, so it was not adopted:\n  %s", kindName, namespace, name, strings.Join(diff, "\n  "))
+	}
+
+	log.Printf("[INFO] Adopting existing %s %s/%s", kindName, namespace, name)
+	return live, nil
+}
+
+// jobDiff returns the fields of the spec, labels and annotations set on
+// planned whose value differs on live. Fields planned leaves unset are not
+// compared, since the server and operator fill them in with defaults.
+func jobDiff(planned interface{}, live interface{}) ([]string, error) {
+	p, err := jobComparable(planned)
+	if err != nil {
+		return nil, err
+	}
+	l, err := jobComparable(live)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff []string
+	diffValues("", p, l, &diff)
+	sort.Strings(diff)
+	return diff, nil
+}
+
+func jobComparable(job interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	metadata, _ := m["metadata"].(map[string]interface{})
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      metadata["labels"],
+			"annotations": metadata["annotations"],
+		},
+		"spec": m["spec"],
+	}, nil
+}
+
+func diffValues(path string, planned interface{}, live interface{}, diff *[]string) {
+	if isZeroValue(planned) {
+		return
+	}
+
+	switch p := planned.(type) {
+	case map[string]interface{}:
+		l, _ := live.(map[string]interface{})
+		for k, v := range p {
+			diffValues(joinPath(path, k), v, l[k], diff)
+		}
+	case []interface{}:
+		l, _ := live.([]interface{})
+		if len(l) != len(p) {
+			*diff = append(*diff, fmt.Sprintf("%s: %d items planned, %d existing", path, len(p), len(l)))
+			return
+		}
+		for i := range p {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), p[i], l[i], diff)
+		}
+	default:
+		if reflect.DeepEqual(planned, live) {
+			return
+		}
+		// Environment and annotation values may hold secrets, so they are not
+		// printed.
+		if strings.Contains(path, ".env[") || strings.Contains(path, ".annotations.") {
+			*diff = append(*diff, fmt.Sprintf("%s: differs", path))
+			return
+		}
+		*diff = append(*diff, fmt.Sprintf("%s: %s planned, %s existing", path, jsonValue(planned), jsonValue(live)))
+	}
+}
+
+func isZeroValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func jsonValue(v interface{}) string {
+	if v == nil {
+		return "unset"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
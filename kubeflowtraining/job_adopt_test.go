@@ -0,0 +1,62 @@
+package kubeflowtraining
+
+import (
+	"reflect"
+	"testing"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestJobDiff(t *testing.T) {
+	newJob := func(image string) *kubeflowv1.PyTorchJob {
+		ptj := testPyTorchJob("")
+		ptj.Labels = map[string]string{"team": "ml"}
+		ptj.Annotations = map[string]string{"example.com/api-token": "planned-token"}
+		ptj.Spec.PyTorchReplicaSpecs = map[commonv1.ReplicaType]*commonv1.ReplicaSpec{
+			kubeflowv1.PyTorchJobReplicaTypeMaster: {
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  "pytorch",
+							Image: image,
+							Env:   []corev1.EnvVar{{Name: "API_KEY", Value: "secret"}},
+						}},
+					},
+				},
+			},
+		}
+		return ptj
+	}
+
+	planned := newJob("pytorch:1")
+
+	live := newJob("pytorch:1")
+	live.ResourceVersion = "42"
+	live.Labels["owner"] = "kubectl"
+	kubeflowv1.SetDefaults_PyTorchJob(live)
+	diff, err := jobDiff(planned, live)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(diff) != 0 {
+		t.Fatalf("expected a defaulted job to match, got %v", diff)
+	}
+
+	live = newJob("pytorch:2")
+	live.Annotations["example.com/api-token"] = "live-token"
+	live.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeMaster].Template.Spec.Containers[0].Env[0].Value = "other"
+	diff, err = jobDiff(planned, live)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []string{
+		"metadata.annotations.example.com/api-token: differs",
+		"spec.pytorchReplicaSpecs.Master.template.spec.containers[0].env[0].value: differs",
+		`spec.pytorchReplicaSpecs.Master.template.spec.containers[0].image: "pytorch:1" planned, "pytorch:2" existing`,
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("expected %v, got %v", expected, diff)
+	}
+}
@@ -0,0 +1,39 @@
+package kubeflowtraining
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// checkJobDestroy refuses to destroy a job that has deletion_protection set,
+// or that is still running while prevent_destroy_while_running is set and
+// force_destroy is not. jobState reads the current state of the job; it is
+// only called when the running guard applies.
+func checkJobDestroy(resourceData *schema.ResourceData, kind string, name string, jobState func() (string, error)) error {
+	if resourceData.Get("deletion_protection").(bool) {
+		return fmt.Errorf("%s %s has deletion_protection enabled; set it to false and apply before destroying the job", kind, name)
+	}
+
+	if !resourceData.Get("prevent_destroy_while_running").(bool) {
+		return nil
+	}
+	if resourceData.Get("force_destroy").(bool) {
+		log.Printf("[INFO] Destroying %s %s regardless of its state, force_destroy is set", kind, name)
+		return nil
+	}
+
+	state, err := jobState()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to check whether %s %s is running: %s", kind, name, err)
+	}
+	if state == "Running" || state == "Restarting" {
+		return fmt.Errorf("%s %s is %s and prevent_destroy_while_running is set; wait for it to finish or set force_destroy to true and apply before destroying it", kind, name, state)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package kubeflowtraining
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCheckJobDestroy(t *testing.T) {
+	notFound := errors.NewNotFound(k8sschema.GroupResource{Group: "kubeflow.org", Resource: "pytorchjobs"}, "test")
+
+	cases := map[string]struct {
+		config map[string]interface{}
+		state  string
+		err    error
+		// checked is whether the state of the job is read.
+		checked  bool
+		expected string
+	}{
+		"unguarded": {
+			config: map[string]interface{}{},
+		},
+		"deletion protection": {
+			config:   map[string]interface{}{"deletion_protection": true},
+			expected: "PyTorchJob test has deletion_protection enabled",
+		},
+		"deletion protection wins over force": {
+			config:   map[string]interface{}{"deletion_protection": true, "prevent_destroy_while_running": true, "force_destroy": true},
+			expected: "PyTorchJob test has deletion_protection enabled",
+		},
+		"running": {
+			config:   map[string]interface{}{"prevent_destroy_while_running": true},
+			state:    "Running",
+			checked:  true,
+			expected: "PyTorchJob test is Running and prevent_destroy_while_running is set",
+		},
+		"restarting": {
+			config:   map[string]interface{}{"prevent_destroy_while_running": true},
+			state:    "Restarting",
+			checked:  true,
+			expected: "PyTorchJob test is Restarting and prevent_destroy_while_running is set",
+		},
+		"finished": {
+			config:  map[string]interface{}{"prevent_destroy_while_running": true},
+			state:   "Succeeded",
+			checked: true,
+		},
+		"forced": {
+			config: map[string]interface{}{"prevent_destroy_while_running": true, "force_destroy": true},
+			state:  "Running",
+		},
+		"already gone": {
+			config:  map[string]interface{}{"prevent_destroy_while_running": true},
+			err:     notFound,
+			checked: true,
+		},
+		"state unknown": {
+			config:   map[string]interface{}{"prevent_destroy_while_running": true},
+			err:      fmt.Errorf("connection refused"),
+			checked:  true,
+			expected: "unable to check whether PyTorchJob test is running: connection refused",
+		},
+	}
+	for name, tc := range cases {
+		resourceData := schema.TestResourceDataRaw(t, resourceKubeFlowPyTorchJob().Schema, tc.config)
+		checked := false
+		err := checkJobDestroy(resourceData, "PyTorchJob", "test", func() (string, error) {
+			checked = true
+			return tc.state, tc.err
+		})
+
+		if checked != tc.checked {
+			t.Errorf("%s: expected the state to be read: %t, was: %t", name, tc.checked, checked)
+		}
+		switch {
+		case tc.expected == "" && err != nil:
+			t.Errorf("%s: unexpected error: %s", name, err)
+		case tc.expected != "" && err == nil:
+			t.Errorf("%s: expected an error containing %q", name, tc.expected)
+		case tc.expected != "" && !strings.Contains(err.Error(), tc.expected):
+			t.Errorf("%s: expected an error containing %q, got %q", name, tc.expected, err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"sort"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	mpiv2beta1 "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+)
+
+// jobKind gives resources that work on a job of any kind access to the typed
+// client calls and status of that kind.
+type jobKind struct {
+	get             func(ctx context.Context, cli client.Client, namespace string, name string) (interface{}, error)
+	watch           func(ctx context.Context, cli client.Client, namespace string, name string, resourceVersion string) (watch.Interface, error)
+	state           func(obj interface{}) string
+	replicaStatuses func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaStatus
+	replicaSpecs    func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaSpec
+	// primaryReplicaTypes are the replica types whose first pod speaks for the
+	// job, e.g. prints its final metrics, in order of preference.
+	primaryReplicaTypes []string
+	// defaultContainer is the name of the container the operator expects to
+	// run the training code.
+	defaultContainer string
+}
+
+var jobKinds = map[string]jobKind{
+	"PyTorchJob": {
+		get: func(ctx context.Context, cli client.Client, namespace string, name string) (interface{}, error) {
+			return cli.GetPyTorchJob(ctx, namespace, name)
+		},
+		watch: func(ctx context.Context, cli client.Client, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+			return cli.WatchPyTorchJob(ctx, namespace, name, resourceVersion)
+		},
+		state: func(obj interface{}) string {
+			return ptjState(obj.(*kubeflowv1.PyTorchJob))
+		},
+		replicaStatuses: func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaStatus {
+			return obj.(*kubeflowv1.PyTorchJob).Status.ReplicaStatuses
+		},
+		replicaSpecs: func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaSpec {
+			return obj.(*kubeflowv1.PyTorchJob).Spec.PyTorchReplicaSpecs
+		},
+		primaryReplicaTypes: []string{"Master", "Worker"},
+		defaultContainer:    kubeflowv1.PytorchJobDefaultContainerName,
+	},
+	"TFJob": {
+		get: func(ctx context.Context, cli client.Client, namespace string, name string) (interface{}, error) {
+			return cli.GetTFJob(ctx, namespace, name)
+		},
+		watch: func(ctx context.Context, cli client.Client, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+			return cli.WatchTFJob(ctx, namespace, name, resourceVersion)
+		},
+		state: func(obj interface{}) string {
+			return tfjState(obj.(*kubeflowv1.TFJob))
+		},
+		replicaStatuses: func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaStatus {
+			return obj.(*kubeflowv1.TFJob).Status.ReplicaStatuses
+		},
+		replicaSpecs: func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaSpec {
+			return obj.(*kubeflowv1.TFJob).Spec.TFReplicaSpecs
+		},
+		primaryReplicaTypes: []string{"Chief", "Master", "Worker"},
+		defaultContainer:    kubeflowv1.TFJobDefaultContainerName,
+	},
+	"MPIJob": {
+		get: func(ctx context.Context, cli client.Client, namespace string, name string) (interface{}, error) {
+			return cli.GetMPIJob(ctx, namespace, name)
+		},
+		watch: func(ctx context.Context, cli client.Client, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+			return cli.WatchMPIJob(ctx, namespace, name, resourceVersion)
+		},
+		state: func(obj interface{}) string {
+			return mpijState(obj.(*mpiv2beta1.MPIJob))
+		},
+		replicaStatuses: func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaStatus {
+			return mpiJobReplicaStatuses(obj.(*mpiv2beta1.MPIJob).Status.ReplicaStatuses)
+		},
+		replicaSpecs: func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaSpec {
+			specs := obj.(*mpiv2beta1.MPIJob).Spec.MPIReplicaSpecs
+			out := make(map[commonv1.ReplicaType]*commonv1.ReplicaSpec, len(specs))
+			for k, v := range specs {
+				out[commonv1.ReplicaType(k)] = v
+			}
+			return out
+		},
+		primaryReplicaTypes: []string{"Launcher"},
+		defaultContainer:    "",
+	},
+	"XGBoostJob": {
+		get: func(ctx context.Context, cli client.Client, namespace string, name string) (interface{}, error) {
+			return cli.GetXGBoostJob(ctx, namespace, name)
+		},
+		watch: func(ctx context.Context, cli client.Client, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+			return cli.WatchXGBoostJob(ctx, namespace, name, resourceVersion)
+		},
+		state: func(obj interface{}) string {
+			return xgbjState(obj.(*kubeflowv1.XGBoostJob))
+		},
+		replicaStatuses: func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaStatus {
+			return obj.(*kubeflowv1.XGBoostJob).Status.ReplicaStatuses
+		},
+		replicaSpecs: func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaSpec {
+			return obj.(*kubeflowv1.XGBoostJob).Spec.XGBReplicaSpecs
+		},
+		primaryReplicaTypes: []string{"Master", "Worker"},
+		defaultContainer:    kubeflowv1.XGBoostJobDefaultContainerName,
+	},
+	"PaddleJob": {
+		get: func(ctx context.Context, cli client.Client, namespace string, name string) (interface{}, error) {
+			return cli.GetPaddleJob(ctx, namespace, name)
+		},
+		watch: func(ctx context.Context, cli client.Client, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+			return cli.WatchPaddleJob(ctx, namespace, name, resourceVersion)
+		},
+		state: func(obj interface{}) string {
+			return pjState(obj.(*kubeflowv1.PaddleJob))
+		},
+		replicaStatuses: func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaStatus {
+			return obj.(*kubeflowv1.PaddleJob).Status.ReplicaStatuses
+		},
+		replicaSpecs: func(obj interface{}) map[commonv1.ReplicaType]*commonv1.ReplicaSpec {
+			return obj.(*kubeflowv1.PaddleJob).Spec.PaddleReplicaSpecs
+		},
+		primaryReplicaTypes: []string{"Master", "Worker"},
+		defaultContainer:    kubeflowv1.PaddleJobDefaultContainerName,
+	},
+}
+
+func jobKindNames() []string {
+	names := make([]string, 0, len(jobKinds))
+	for name := range jobKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
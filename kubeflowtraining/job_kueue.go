@@ -0,0 +1,68 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// admission explains why a suspended job has not started: Kueue creates a
+// Workload for every job it queues and records on it why the job is not
+// admitted yet, e.g. that its ClusterQueue lacks quota.
+func (p *jobProgress) admission(ctx context.Context) string {
+	workloads, err := p.cli.ListKueueObjects(ctx, "workloads", p.namespace)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to list Kueue workloads of %s %s: %s", p.kind, p.name, err)
+		return "Pending admission"
+	}
+	for _, w := range workloads {
+		if !ownedBy(w, p.kind, p.name) {
+			continue
+		}
+		if reason := workloadPendingReason(w); reason != "" {
+			return fmt.Sprintf("Pending admission by Kueue (workload %s): %s", w.GetName(), reason)
+		}
+		return fmt.Sprintf("Pending admission by Kueue (workload %s)", w.GetName())
+	}
+	return "Pending admission"
+}
+
+// describe explains a suspended job in timeout errors.
+func (p *jobProgress) describe(ctx context.Context, obj interface{}, state string) string {
+	if state != "Suspended" {
+		return ""
+	}
+	return p.admission(ctx)
+}
+
+func ownedBy(obj unstructured.Unstructured, kind string, name string) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == kind && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// workloadPendingReason returns the message of the first false QuotaReserved
+// or Admitted condition of a Kueue workload.
+func workloadPendingReason(w unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(w.Object, "status", "conditions")
+	for _, t := range []string{"QuotaReserved", "Admitted"} {
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if !ok || condition["type"] != t || condition["status"] != "False" {
+				continue
+			}
+			if message, _ := condition["message"].(string); message != "" {
+				return message
+			}
+			if reason, _ := condition["reason"].(string); reason != "" {
+				return reason
+			}
+		}
+	}
+	return ""
+}
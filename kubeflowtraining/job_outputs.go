@@ -0,0 +1,67 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+)
+
+// setJobOutputs sets the outputs of a succeeded job from the termination
+// message of its outputs replica. Jobs that report nothing, whose pods are
+// already cleaned up or whose message is not a JSON object get empty outputs
+// and a warning: the job has succeeded, failing here would taint it and make
+// the next apply train it again.
+func setJobOutputs(ctx context.Context, cli client.Client, resourceData *schema.ResourceData, kindName string, namespace string, name string) error {
+	outputs := map[string]string{}
+
+	pod, err := findJobPod(ctx, cli, kindName, namespace, name, resourceData.Get("outputs_replica_type").(string), 0)
+	if err != nil {
+		log.Printf("[WARN] Unable to read the outputs of %s %s: %s", kindName, name, err)
+		return resourceData.Set("outputs", outputs)
+	}
+
+	container := jobPodContainer(pod, kindName, "")
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != container || cs.State.Terminated == nil {
+			continue
+		}
+		parsed, err := parseTerminationMessage(cs.State.Terminated.Message)
+		if err != nil {
+			log.Printf("[WARN] Unable to read the outputs of %s %s from pod %s: %s", kindName, name, pod.Name, err)
+			continue
+		}
+		outputs = parsed
+	}
+
+	log.Printf("[DEBUG] %s %s reported %d outputs", kindName, name, len(outputs))
+	return resourceData.Set("outputs", outputs)
+}
+
+// parseTerminationMessage parses a termination message holding a JSON object.
+// String values are kept as-is, other values are kept as JSON.
+func parseTerminationMessage(message string) (map[string]string, error) {
+	result := map[string]string{}
+	if strings.TrimSpace(message) == "" {
+		return result, nil
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(message), &values); err != nil {
+		return nil, fmt.Errorf("termination message is not a JSON object: %s", err)
+	}
+	for k, v := range values {
+		var s string
+		if err := json.Unmarshal(v, &s); err == nil {
+			result[k] = s
+			continue
+		}
+		result[k] = string(v)
+	}
+	return result, nil
+}
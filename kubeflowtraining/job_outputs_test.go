@@ -0,0 +1,59 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client/mock"
+)
+
+func TestParseTerminationMessage(t *testing.T) {
+	testCases := []struct {
+		Message  string
+		Expected map[string]string
+		Error    bool
+	}{
+		{"", map[string]string{}, false},
+		{`{"model_uri": "s3://models/1", "loss": 0.25, "tags": ["a"]}`, map[string]string{"model_uri": "s3://models/1", "loss": "0.25", "tags": `["a"]`}, false},
+		{"training done", nil, true},
+	}
+
+	for i, tc := range testCases {
+		outputs, err := parseTerminationMessage(tc.Message)
+		if (err != nil) != tc.Error {
+			t.Fatalf("%d: unexpected error: %v", i, err)
+		}
+		if !tc.Error && !reflect.DeepEqual(outputs, tc.Expected) {
+			t.Fatalf("%d: expected %v, got %v", i, tc.Expected, outputs)
+		}
+	}
+}
+
+func TestSetJobOutputsInvalidMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pod := testJobPod("test-master-0", "master", "0", "pytorch")
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "pytorch",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "training done"}},
+	}}
+	cli := mock.NewMockClient(ctrl)
+	cli.EXPECT().GetPyTorchJob(gomock.Any(), "default", "test").Return(testPyTorchJob("1", commonv1.JobSucceeded), nil)
+	cli.EXPECT().ListPods(gomock.Any(), "default", commonv1.JobNameLabel+"=test").Return([]corev1.Pod{pod}, nil)
+
+	// The job has succeeded, so a message that is not JSON must not fail it.
+	resourceData := schema.TestResourceDataRaw(t, resourceKubeFlowPyTorchJob().Schema, map[string]interface{}{})
+	if err := setJobOutputs(context.Background(), cli, resourceData, "PyTorchJob", "default", "test"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if outputs := resourceData.Get("outputs").(map[string]interface{}); len(outputs) != 0 {
+		t.Fatalf("expected no outputs, got %v", outputs)
+	}
+}
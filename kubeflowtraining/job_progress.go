@@ -0,0 +1,160 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+)
+
+// jobProgressInterval is how often a progress summary is logged while
+// waiting for a job.
+const jobProgressInterval = 30 * time.Second
+
+// jobProgress logs INFO-level summaries of a job while the provider waits
+// for it: its state, the pod counts per replica type, why pending pods are
+// not running and the events recorded since the previous summary. Failing to
+// gather any of this is logged and otherwise ignored, it never fails the wait.
+type jobProgress struct {
+	cli       client.Client
+	kind      string
+	namespace string
+	name      string
+
+	// since is the time of the newest event already logged, and logged the
+	// resourceVersions of the events logged at that time, by UID, so that
+	// events sharing it are neither dropped nor logged twice.
+	since  time.Time
+	logged map[types.UID]string
+}
+
+func newJobProgress(cli client.Client, kind string, namespace string, name string) *jobProgress {
+	return &jobProgress{
+		cli:       cli,
+		kind:      kind,
+		namespace: namespace,
+		name:      name,
+		since:     time.Now(),
+		logged:    map[types.UID]string{},
+	}
+}
+
+func (p *jobProgress) log(ctx context.Context, state string, replicaStatuses map[commonv1.ReplicaType]*commonv1.ReplicaStatus) {
+	if state == "Suspended" {
+		log.Printf("[INFO] %s %s/%s is %s", p.kind, p.namespace, p.name, p.admission(ctx))
+	} else {
+		log.Printf("[INFO] %s %s/%s is %s%s", p.kind, p.namespace, p.name, state, formatReplicaStatuses(replicaStatuses))
+	}
+
+	pods, err := p.cli.ListPods(ctx, p.namespace, labels.SelectorFromSet(labels.Set{commonv1.JobNameLabel: p.name}).String())
+	if err != nil {
+		log.Printf("[DEBUG] Unable to list pods of %s %s: %s", p.kind, p.name, err)
+		return
+	}
+	involved := []string{p.name}
+	for _, pod := range pods {
+		involved = append(involved, pod.Name)
+		if pod.Status.Phase == corev1.PodPending {
+			log.Printf("[INFO] %s %s/%s: pod %s is Pending: %s", p.kind, p.namespace, p.name, pod.Name, podPendingReason(pod))
+		}
+	}
+
+	p.logEvents(ctx, involved)
+}
+
+// logEvents logs the events of the job and its pods not logged yet.
+func (p *jobProgress) logEvents(ctx context.Context, involved []string) {
+	for _, e := range p.newEvents(ctx, involved) {
+		log.Printf("[INFO] %s %s/%s: %s %s on %s/%s: %s", p.kind, p.namespace, p.name, e.Type, e.Reason, e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Message)
+	}
+}
+
+// newEvents returns the events of the objects named involved recorded since
+// p.since that have not been returned before, oldest first. It lists the
+// events of the namespace once rather than once per object.
+func (p *jobProgress) newEvents(ctx context.Context, involved []string) []corev1.Event {
+	list, err := p.cli.ListEvents(ctx, p.namespace, "")
+	if err != nil {
+		log.Printf("[DEBUG] Unable to list events of %s %s: %s", p.kind, p.name, err)
+		return nil
+	}
+	names := make(map[string]bool, len(involved))
+	for _, name := range involved {
+		names[name] = true
+	}
+
+	var events []corev1.Event
+	for _, e := range list {
+		if !names[e.InvolvedObject.Name] || eventTime(e).Before(p.since) {
+			continue
+		}
+		// An event is updated, with a new resourceVersion, when it recurs.
+		if rv, ok := p.logged[e.UID]; ok && rv == e.ResourceVersion {
+			continue
+		}
+		events = append(events, e)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return eventTime(events[i]).Before(eventTime(events[j])) })
+	for _, e := range events {
+		if t := eventTime(e); t.After(p.since) {
+			p.since = t
+			p.logged = map[types.UID]string{}
+		}
+		p.logged[e.UID] = e.ResourceVersion
+	}
+	return events
+}
+
+func formatReplicaStatuses(in map[commonv1.ReplicaType]*commonv1.ReplicaStatus) string {
+	types := make([]string, 0, len(in))
+	for t := range in {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+
+	var b strings.Builder
+	for _, t := range types {
+		rs := in[commonv1.ReplicaType(t)]
+		if rs == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "; %s: %d active, %d succeeded, %d failed", t, rs.Active, rs.Succeeded, rs.Failed)
+	}
+	return b.String()
+}
+
+// podPendingReason explains why a pod is pending, preferring the scheduler's
+// verdict over container waiting reasons such as ImagePullBackOff.
+func podPendingReason(pod corev1.Pod) string {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			return fmt.Sprintf("%s: %s", c.Reason, c.Message)
+		}
+	}
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+			return fmt.Sprintf("container %s %s: %s", cs.Name, cs.State.Waiting.Reason, cs.State.Waiting.Message)
+		}
+	}
+	return "waiting to be scheduled"
+}
+
+func eventTime(e corev1.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	if !e.EventTime.IsZero() {
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
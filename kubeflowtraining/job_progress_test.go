@@ -0,0 +1,130 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client/mock"
+)
+
+func TestFormatReplicaStatuses(t *testing.T) {
+	cases := map[string]struct {
+		in       map[commonv1.ReplicaType]*commonv1.ReplicaStatus
+		expected string
+	}{
+		"none": {},
+		"sorted by type, skipping nil": {
+			in: map[commonv1.ReplicaType]*commonv1.ReplicaStatus{
+				"Worker":    {Active: 3, Failed: 1},
+				"Master":    {Active: 1},
+				"Evaluator": nil,
+			},
+			expected: "; Master: 1 active, 0 succeeded, 0 failed; Worker: 3 active, 0 succeeded, 1 failed",
+		},
+	}
+	for name, tc := range cases {
+		if actual := formatReplicaStatuses(tc.in); actual != tc.expected {
+			t.Errorf("%s: expected %q, got %q", name, tc.expected, actual)
+		}
+	}
+}
+
+func TestPodPendingReason(t *testing.T) {
+	unschedulable := corev1.PodCondition{
+		Type:    corev1.PodScheduled,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Unschedulable",
+		Message: "0/4 nodes are available: 4 Insufficient nvidia.com/gpu.",
+	}
+	pulling := corev1.ContainerStatus{
+		Name:  "pytorch",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+	}
+
+	cases := map[string]struct {
+		status   corev1.PodStatus
+		expected string
+	}{
+		"unscheduled": {
+			status:   corev1.PodStatus{},
+			expected: "waiting to be scheduled",
+		},
+		"scheduler verdict first": {
+			status:   corev1.PodStatus{Conditions: []corev1.PodCondition{unschedulable}, ContainerStatuses: []corev1.ContainerStatus{pulling}},
+			expected: "Unschedulable: 0/4 nodes are available: 4 Insufficient nvidia.com/gpu.",
+		},
+		"container waiting": {
+			status:   corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{pulling}},
+			expected: "container pytorch ImagePullBackOff: Back-off pulling image",
+		},
+	}
+	for name, tc := range cases {
+		if actual := podPendingReason(corev1.Pod{Status: tc.status}); actual != tc.expected {
+			t.Errorf("%s: expected %q, got %q", name, tc.expected, actual)
+		}
+	}
+}
+
+func TestJobProgressNewEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	since := time.Now().Truncate(time.Second)
+	event := func(uid string, resourceVersion string, involved string, at time.Time) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{UID: types.UID(uid), ResourceVersion: resourceVersion},
+			InvolvedObject: corev1.ObjectReference{Name: involved},
+			LastTimestamp:  metav1.NewTime(at),
+		}
+	}
+	uids := func(events []corev1.Event) []string {
+		var out []string
+		for _, e := range events {
+			out = append(out, string(e.UID))
+		}
+		return out
+	}
+
+	cli := mock.NewMockClient(ctrl)
+	progress := newJobProgress(cli, "PyTorchJob", "default", "test")
+	progress.since = since
+	involved := []string{"test", "test-master-0"}
+
+	gomock.InOrder(
+		cli.EXPECT().ListEvents(gomock.Any(), "default", "").Return([]corev1.Event{
+			event("scheduled", "1", "test-master-0", since.Add(time.Second)),
+			event("created", "1", "test", since),
+			event("old", "1", "test", since.Add(-time.Second)),
+			event("other", "1", "other-job", since.Add(time.Second)),
+		}, nil),
+		cli.EXPECT().ListEvents(gomock.Any(), "default", "").Return([]corev1.Event{
+			event("scheduled", "1", "test-master-0", since.Add(time.Second)),
+			// Recorded in the same second as the newest event logged.
+			event("pulled", "1", "test-master-0", since.Add(time.Second)),
+			event("created", "1", "test", since),
+		}, nil),
+		cli.EXPECT().ListEvents(gomock.Any(), "default", "").Return([]corev1.Event{
+			// The scheduling failure recurred.
+			event("scheduled", "2", "test-master-0", since.Add(2*time.Second)),
+			event("pulled", "1", "test-master-0", since.Add(time.Second)),
+		}, nil),
+	)
+
+	for i, expected := range [][]string{
+		{"created", "scheduled"},
+		{"pulled"},
+		{"scheduled"},
+	} {
+		if actual := uids(progress.newEvents(context.Background(), involved)); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("list %d: expected events %v, got %v", i, expected, actual)
+		}
+	}
+}
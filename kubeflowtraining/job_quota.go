@@ -0,0 +1,311 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+)
+
+// replicaPods are the pods of a replica type of a job, with the resources
+// of each pod as the LimitRanges of the namespace default them.
+type replicaPods struct {
+	replicaType string
+	count       int64
+	containers  []corev1.Container
+	// requests and limits are the effective resources of a pod: the sum over
+	// its containers, or the largest init container if that is larger.
+	requests corev1.ResourceList
+	limits   corev1.ResourceList
+}
+
+// checkJobQuota implements quota_preflight: it fails when the pods of job
+// would be rejected by the LimitRanges of namespace or exceed what is left
+// of its ResourceQuotas, which would otherwise leave the job pending with
+// pods the operator cannot create. The check is skipped when the job already
+// exists, as its pods are already counted in the quotas.
+func checkJobQuota(ctx context.Context, cli client.Client, resourceData *schema.ResourceData, kindName string, namespace string, name string, job interface{}) error {
+	if !resourceData.Get("quota_preflight").(bool) {
+		return nil
+	}
+	if _, err := jobKinds[kindName].get(ctx, cli, namespace, name); err == nil {
+		log.Printf("[INFO] Not checking the quotas of %s %s/%s, which already exists", kindName, namespace, name)
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return fmt.Errorf("quota_preflight: unable to check whether %s %s/%s exists: %s", kindName, namespace, name, err)
+	}
+
+	limitRanges, err := cli.ListLimitRanges(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("quota_preflight: unable to list the LimitRanges of namespace %s: %s", namespace, err)
+	}
+	quotas, err := cli.ListResourceQuotas(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("quota_preflight: unable to list the ResourceQuotas of namespace %s: %s", namespace, err)
+	}
+
+	replicas := expandReplicaPods(jobKinds[kindName].replicaSpecs(job), limitRanges)
+	problems := append(limitRangeViolations(replicas, limitRanges), quotaViolations(replicas, quotas)...)
+	if len(problems) == 0 {
+		log.Printf("[INFO] %s %s/%s fits in the quotas of its namespace", kindName, namespace, name)
+		return nil
+	}
+	return fmt.Errorf("quota_preflight: %s %s/%s does not fit in namespace %s:\n  %s", kindName, namespace, name, namespace, strings.Join(problems, "\n  "))
+}
+
+// expandReplicaPods returns the pods of each replica type, sorted by type,
+// with the container defaults of limitRanges applied.
+func expandReplicaPods(specs map[commonv1.ReplicaType]*commonv1.ReplicaSpec, limitRanges []corev1.LimitRange) []replicaPods {
+	var out []replicaPods
+	for t, spec := range specs {
+		if spec == nil {
+			continue
+		}
+		rp := replicaPods{
+			replicaType: string(t),
+			count:       1,
+			requests:    corev1.ResourceList{},
+			limits:      corev1.ResourceList{},
+		}
+		if spec.Replicas != nil {
+			rp.count = int64(*spec.Replicas)
+		}
+
+		for _, c := range spec.Template.Spec.Containers {
+			c = defaultContainerResources(c, limitRanges)
+			rp.containers = append(rp.containers, c)
+			addResources(rp.requests, c.Resources.Requests)
+			addResources(rp.limits, c.Resources.Limits)
+		}
+		for _, c := range spec.Template.Spec.InitContainers {
+			c = defaultContainerResources(c, limitRanges)
+			rp.containers = append(rp.containers, c)
+			maxResources(rp.requests, c.Resources.Requests)
+			maxResources(rp.limits, c.Resources.Limits)
+		}
+		out = append(out, rp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].replicaType < out[j].replicaType })
+	return out
+}
+
+// defaultContainerResources defaults the resources of c as the API server
+// does: requests to limits, then the defaults of the Container limits of
+// limitRanges.
+func defaultContainerResources(c corev1.Container, limitRanges []corev1.LimitRange) corev1.Container {
+	c = *c.DeepCopy()
+	if c.Resources.Requests == nil {
+		c.Resources.Requests = corev1.ResourceList{}
+	}
+	if c.Resources.Limits == nil {
+		c.Resources.Limits = corev1.ResourceList{}
+	}
+	for r, q := range c.Resources.Limits {
+		if _, ok := c.Resources.Requests[r]; !ok {
+			c.Resources.Requests[r] = q.DeepCopy()
+		}
+	}
+	for _, lr := range limitRanges {
+		for _, item := range lr.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+			for r, q := range item.Default {
+				if _, ok := c.Resources.Limits[r]; !ok {
+					c.Resources.Limits[r] = q.DeepCopy()
+				}
+			}
+			// A LimitRange defaults defaultRequest to default.
+			defaultRequest := item.DefaultRequest
+			if len(defaultRequest) == 0 {
+				defaultRequest = item.Default
+			}
+			for r, q := range defaultRequest {
+				if _, ok := c.Resources.Requests[r]; !ok {
+					c.Resources.Requests[r] = q.DeepCopy()
+				}
+			}
+		}
+	}
+	return c
+}
+
+// limitRangeViolations returns the bounds of the Container and Pod limits of
+// limitRanges that the pods of replicas are outside of.
+func limitRangeViolations(replicas []replicaPods, limitRanges []corev1.LimitRange) []string {
+	var problems []string
+	for _, lr := range limitRanges {
+		for _, item := range lr.Spec.Limits {
+			for _, rp := range replicas {
+				switch item.Type {
+				case corev1.LimitTypeContainer:
+					for _, c := range rp.containers {
+						subject := fmt.Sprintf("container %s of %s", c.Name, rp.replicaType)
+						problems = append(problems, boundViolations(subject, lr.Name, item, c.Resources.Requests, c.Resources.Limits)...)
+					}
+				case corev1.LimitTypePod:
+					subject := fmt.Sprintf("pods of %s", rp.replicaType)
+					problems = append(problems, boundViolations(subject, lr.Name, item, rp.requests, rp.limits)...)
+				}
+			}
+		}
+	}
+	return problems
+}
+
+func boundViolations(subject string, limitRange string, item corev1.LimitRangeItem, requests corev1.ResourceList, limits corev1.ResourceList) []string {
+	var problems []string
+	for _, r := range sortedResourceNames(item.Min) {
+		min := item.Min[r]
+		if q, ok := requests[r]; !ok || q.Cmp(min) < 0 {
+			problems = append(problems, fmt.Sprintf("%s: %s request %s is below the minimum %s of LimitRange %s", subject, r, quantityOrNone(requests, r), min.String(), limitRange))
+		}
+	}
+	for _, r := range sortedResourceNames(item.Max) {
+		max := item.Max[r]
+		if q, ok := limits[r]; !ok || q.Cmp(max) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: %s limit %s is above the maximum %s of LimitRange %s", subject, r, quantityOrNone(limits, r), max.String(), limitRange))
+		}
+	}
+	return problems
+}
+
+// quotaViolations returns the resources of quotas the pods of replicas
+// exceed what is left of, or must set and do not. Quotas restricted to
+// scopes are not checked, as they may not apply to the pods.
+func quotaViolations(replicas []replicaPods, quotas []corev1.ResourceQuota) []string {
+	demand := jobQuotaDemand(replicas)
+
+	var problems []string
+	for _, quota := range quotas {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			log.Printf("[DEBUG] Not checking ResourceQuota %s, which is scoped", quota.Name)
+			continue
+		}
+		for _, r := range sortedResourceNames(quota.Spec.Hard) {
+			hard := quota.Spec.Hard[r]
+			if missing := replicasMissing(replicas, r); len(missing) > 0 {
+				problems = append(problems, fmt.Sprintf("%s: not set on pods of %s, which ResourceQuota %s requires", r, strings.Join(missing, ", "), quota.Name))
+				continue
+			}
+			d, ok := demand[r]
+			if !ok {
+				continue
+			}
+			left := hard.DeepCopy()
+			if used, ok := quota.Status.Used[r]; ok {
+				left.Sub(used)
+			}
+			if d.total.Cmp(left) > 0 {
+				problems = append(problems, fmt.Sprintf("%s: the job needs %s (%s), ResourceQuota %s has %s of %s left", r, d.total.String(), strings.Join(d.parts, ", "), quota.Name, left.String(), hard.String()))
+			}
+		}
+	}
+	return problems
+}
+
+type quotaDemand struct {
+	total resource.Quantity
+	parts []string
+}
+
+// jobQuotaDemand returns what the pods of replicas count against the
+// resources of a quota, e.g. requests.cpu, limits.memory or pods.
+func jobQuotaDemand(replicas []replicaPods) map[corev1.ResourceName]*quotaDemand {
+	demand := map[corev1.ResourceName]*quotaDemand{}
+	add := func(r corev1.ResourceName, rp replicaPods, q resource.Quantity) {
+		d, ok := demand[r]
+		if !ok {
+			d = &quotaDemand{}
+			demand[r] = d
+		}
+		for i := int64(0); i < rp.count; i++ {
+			d.total.Add(q)
+		}
+		d.parts = append(d.parts, fmt.Sprintf("%s: %d x %s", rp.replicaType, rp.count, q.String()))
+	}
+
+	for _, rp := range replicas {
+		add(corev1.ResourcePods, rp, *resource.NewQuantity(1, resource.DecimalSI))
+		for _, r := range sortedResourceNames(rp.requests) {
+			add(corev1.ResourceName("requests."+string(r)), rp, rp.requests[r])
+			if isComputeResource(r) {
+				add(r, rp, rp.requests[r])
+			}
+		}
+		for _, r := range sortedResourceNames(rp.limits) {
+			add(corev1.ResourceName("limits."+string(r)), rp, rp.limits[r])
+		}
+	}
+	return demand
+}
+
+// replicasMissing returns the replica types whose pods do not set the
+// compute resource a quota tracks as r, which makes the quota reject them.
+func replicasMissing(replicas []replicaPods, r corev1.ResourceName) []string {
+	list := func(rp replicaPods) corev1.ResourceList { return rp.requests }
+	name := corev1.ResourceName(strings.TrimPrefix(string(r), "requests."))
+	if strings.HasPrefix(string(r), "limits.") {
+		list = func(rp replicaPods) corev1.ResourceList { return rp.limits }
+		name = corev1.ResourceName(strings.TrimPrefix(string(r), "limits."))
+	}
+	// Other resources are only counted when pods request them.
+	if !isComputeResource(name) {
+		return nil
+	}
+
+	var missing []string
+	for _, rp := range replicas {
+		if _, ok := list(rp)[name]; !ok {
+			missing = append(missing, rp.replicaType)
+		}
+	}
+	return missing
+}
+
+// isComputeResource reports whether r is a standard resource that quotas
+// also take the bare name of, as requests.
+func isComputeResource(r corev1.ResourceName) bool {
+	return r == corev1.ResourceCPU || r == corev1.ResourceMemory || r == corev1.ResourceEphemeralStorage
+}
+
+func addResources(total corev1.ResourceList, l corev1.ResourceList) {
+	for r, q := range l {
+		sum := total[r]
+		sum.Add(q)
+		total[r] = sum
+	}
+}
+
+func maxResources(total corev1.ResourceList, l corev1.ResourceList) {
+	for r, q := range l {
+		if current, ok := total[r]; !ok || q.Cmp(current) > 0 {
+			total[r] = q.DeepCopy()
+		}
+	}
+}
+
+func sortedResourceNames(l corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(l))
+	for r := range l {
+		names = append(names, r)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+func quantityOrNone(l corev1.ResourceList, r corev1.ResourceName) string {
+	if q, ok := l[r]; ok {
+		return q.String()
+	}
+	return "(none)"
+}
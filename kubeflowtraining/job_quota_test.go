@@ -0,0 +1,92 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client/mock"
+)
+
+func TestCheckJobQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	replicaSpec := func(replicas int32, cpu string) *commonv1.ReplicaSpec {
+		return &commonv1.ReplicaSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "pytorch",
+				Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{
+					corev1.ResourceCPU:                    resource.MustParse(cpu),
+					corev1.ResourceName("nvidia.com/gpu"): resource.MustParse("2"),
+				}},
+			}}}},
+		}
+	}
+	ptj := testPyTorchJob("")
+	ptj.Spec.PyTorchReplicaSpecs = map[commonv1.ReplicaType]*commonv1.ReplicaSpec{
+		kubeflowv1.PyTorchJobReplicaTypeMaster: replicaSpec(1, "8"),
+		kubeflowv1.PyTorchJobReplicaTypeWorker: replicaSpec(3, "4"),
+	}
+
+	cli := mock.NewMockClient(ctrl)
+	notFound := errors.NewNotFound(k8sschema.GroupResource{Group: "kubeflow.org", Resource: "pytorchjobs"}, "test")
+	gomock.InOrder(
+		cli.EXPECT().GetPyTorchJob(gomock.Any(), "default", "test").Return(nil, notFound),
+		cli.EXPECT().GetPyTorchJob(gomock.Any(), "default", "test").Return(testPyTorchJob("1"), nil),
+	)
+	cli.EXPECT().ListLimitRanges(gomock.Any(), "default").Return([]corev1.LimitRange{{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits"},
+		Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+			Type:    corev1.LimitTypeContainer,
+			Max:     corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			Default: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}}},
+	}}, nil)
+	cli.EXPECT().ListResourceQuotas(gomock.Any(), "default").Return([]corev1.ResourceQuota{{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpus"},
+		Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{
+			corev1.ResourceName("requests.nvidia.com/gpu"): resource.MustParse("8"),
+			corev1.ResourceRequestsMemory:                  resource.MustParse("16Gi"),
+		}},
+		Status: corev1.ResourceQuotaStatus{Used: corev1.ResourceList{
+			corev1.ResourceName("requests.nvidia.com/gpu"): resource.MustParse("2"),
+		}},
+	}}, nil)
+
+	resourceData := schema.TestResourceDataRaw(t, resourceKubeFlowPyTorchJob().Schema, map[string]interface{}{
+		"quota_preflight": true,
+	})
+	err := checkJobQuota(context.Background(), cli, resourceData, "PyTorchJob", "default", "test", ptj)
+	if err == nil {
+		t.Fatal("expected the job not to fit")
+	}
+	for _, expected := range []string{
+		"container pytorch of Master: cpu limit 8 is above the maximum 4 of LimitRange limits",
+		"requests.nvidia.com/gpu: the job needs 8 (Master: 1 x 2, Worker: 3 x 2), ResourceQuota gpus has 6 of 8 left",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in error:\n%s", expected, err)
+		}
+	}
+	// The memory the LimitRange defaults fits in the quota.
+	if strings.Contains(err.Error(), "requests.memory") {
+		t.Errorf("unexpected memory violation in error:\n%s", err)
+	}
+
+	// The pods of a job that already exists are counted in the quotas.
+	if err := checkJobQuota(context.Background(), cli, resourceData, "PyTorchJob", "default", "test", ptj); err != nil {
+		t.Errorf("expected the quotas of an existing job not to be checked, got: %s", err)
+	}
+}
@@ -0,0 +1,70 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+)
+
+// schedulingCheckTimeout bounds the lookups of the plan-time checks.
+const schedulingCheckTimeout = 30 * time.Second
+
+// customizeDiffSchedulingPolicy fails the plan when the scheduling policy of
+// a job refers to a Volcano queue or PriorityClass that does not exist, which
+// would otherwise leave the job's pods pending.
+func customizeDiffSchedulingPolicy(diff *schema.ResourceDiff, meta interface{}) error {
+	cli, ok := meta.(client.Client)
+	if !ok {
+		return nil
+	}
+
+	var queue, priorityClass string
+	for key, v := range map[string]*string{
+		"spec.0.run_policy.0.scheduling_policy.0.queue":          &queue,
+		"spec.0.run_policy.0.scheduling_policy.0.priority_class": &priorityClass,
+	} {
+		// Only check references that change, so that jobs are not stuck on
+		// objects removed after they were scheduled.
+		if diff.HasChange(key) && diff.NewValueKnown(key) {
+			*v = diff.Get(key).(string)
+		}
+	}
+	if queue == "" && priorityClass == "" {
+		return nil
+	}
+
+	parent := context.Background()
+	if m, ok := meta.(*providerMeta); ok && m.stopContext != nil {
+		parent = m.stopContext
+	}
+	ctx, cancel := context.WithTimeout(parent, schedulingCheckTimeout)
+	defer cancel()
+	return checkSchedulingPolicy(ctx, cli, queue, priorityClass)
+}
+
+// checkSchedulingPolicy checks that the named queue and PriorityClass exist,
+// skipping empty names.
+func checkSchedulingPolicy(ctx context.Context, cli client.Client, queue string, priorityClass string) error {
+	if queue != "" {
+		if _, err := cli.GetVolcanoQueue(ctx, queue); err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("scheduling_policy: Volcano queue %q does not exist, or Volcano is not installed", queue)
+			}
+			return err
+		}
+	}
+	if priorityClass != "" {
+		if _, err := cli.GetPriorityClass(ctx, priorityClass); err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("scheduling_policy: PriorityClass %q does not exist", priorityClass)
+			}
+			return err
+		}
+	}
+	return nil
+}
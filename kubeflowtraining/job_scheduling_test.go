@@ -0,0 +1,65 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client/mock"
+)
+
+func TestCheckSchedulingPolicy(t *testing.T) {
+	notFound := apierrors.NewNotFound(k8sschema.GroupResource{Resource: "queues"}, "missing")
+
+	testCases := []struct {
+		name          string
+		queue         string
+		priorityClass string
+		expectedErr   string
+	}{
+		{
+			name:          "existing references",
+			queue:         "default",
+			priorityClass: "high",
+		},
+		{
+			name:        "missing queue",
+			queue:       "missing",
+			expectedErr: `scheduling_policy: Volcano queue "missing" does not exist, or Volcano is not installed`,
+		},
+		{
+			name:          "missing priority class",
+			priorityClass: "missing",
+			expectedErr:   `scheduling_policy: PriorityClass "missing" does not exist`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cli := mock.NewMockClient(ctrl)
+			cli.EXPECT().GetVolcanoQueue(gomock.Any(), "default").Return(&unstructured.Unstructured{}, nil).AnyTimes()
+			cli.EXPECT().GetVolcanoQueue(gomock.Any(), "missing").Return(nil, notFound).AnyTimes()
+			cli.EXPECT().GetPriorityClass(gomock.Any(), "high").Return(&schedulingv1.PriorityClass{}, nil).AnyTimes()
+			cli.EXPECT().GetPriorityClass(gomock.Any(), "missing").Return(nil, notFound).AnyTimes()
+
+			err := checkSchedulingPolicy(context.Background(), cli, tc.queue, tc.priorityClass)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
+)
+
+// updateConflictRetries is how many times an update rejected because the job
+// changed concurrently is retried against the re-read job.
+const updateConflictRetries = 3
+
+// updateJob applies ops to a job through update, guarded by a test of the
+// resourceVersion Terraform last read. When the job changed in the meantime,
+// it is re-read: changes to fields Terraform manages are reported as drift,
+// other changes are retried against the new resourceVersion.
+func updateJob(ctx context.Context, cli client.Client, resourceData *schema.ResourceData, kindName string, namespace string, name string, ops patch.PatchOperations, update func(data []byte) error) error {
+	resourceVersion := resourceData.Get("metadata.0.resource_version").(string)
+
+	for attempt := 0; ; attempt++ {
+		data, err := ops.WithResourceVersionTest(resourceVersion).MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("failed to marshal update operations: %s", err)
+		}
+
+		err = update(data)
+		if err == nil || !client.IsConflict(err) {
+			return err
+		}
+		if attempt == updateConflictRetries {
+			return fmt.Errorf("%s %s/%s kept changing while it was being updated: %s", kindName, namespace, name, err)
+		}
+
+		obj, err := jobKinds[kindName].get(ctx, cli, namespace, name)
+		if err != nil {
+			return fmt.Errorf("unable to re-read %s %s/%s after a conflicting update: %s", kindName, namespace, name, err)
+		}
+		live, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		if drift := managedMetadataDrift(resourceData, live.GetLabels(), live.GetAnnotations()); len(drift) > 0 {
+			return fmt.Errorf("%s %s/%s was changed outside of Terraform since it was last read, in %s; refresh and review the plan before applying again", kindName, namespace, name, strings.Join(drift, ", "))
+		}
+
+		log.Printf("[DEBUG] %s %s changed from resourceVersion %s to %s without touching managed fields, retrying update", kindName, name, resourceVersion, live.GetResourceVersion())
+		resourceVersion = live.GetResourceVersion()
+	}
+}
+
+// managedMetadataDrift returns the labels and annotations Terraform manages
+// whose live value no longer matches the last one Terraform read.
+func managedMetadataDrift(resourceData *schema.ResourceData, labels map[string]string, annotations map[string]string) []string {
+	var drift []string
+	for key, live := range map[string]map[string]string{"labels": labels, "annotations": annotations} {
+		oldV, newV := resourceData.GetChange("metadata.0." + key)
+		old, _ := oldV.(map[string]interface{})
+		managed := map[string]bool{}
+		for k := range old {
+			managed[k] = true
+		}
+		for k := range newV.(map[string]interface{}) {
+			managed[k] = true
+		}
+
+		for k := range managed {
+			oldValue, had := old[k]
+			liveValue, has := live[k]
+			if had != has || (has && oldValue.(string) != liveValue) {
+				drift = append(drift, fmt.Sprintf("metadata.0.%s.%s", key, k))
+			}
+		}
+	}
+	sort.Strings(drift)
+	return drift
+}
@@ -0,0 +1,87 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client/mock"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
+)
+
+func TestUpdateJobConflict(t *testing.T) {
+	conflict := apierrors.NewConflict(k8sschema.GroupResource{Resource: "pytorchjobs"}, "test", nil)
+
+	testCases := []struct {
+		name        string
+		liveLabels  map[string]string
+		expectedErr string
+		expectedRVs []string
+	}{
+		{
+			name:        "retried when unmanaged fields changed",
+			expectedRVs: []string{"1", "2"},
+		},
+		{
+			name:        "drift when managed fields changed",
+			liveLabels:  map[string]string{"team": "other"},
+			expectedErr: "metadata.0.labels.team",
+			expectedRVs: []string{"1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			live := testPyTorchJob("2")
+			live.Labels = tc.liveLabels
+			cli := mock.NewMockClient(ctrl)
+			cli.EXPECT().GetPyTorchJob(gomock.Any(), "default", "test").Return(live, nil)
+
+			resourceData := schema.TestResourceDataRaw(t, resourceKubeFlowPyTorchJob().Schema, map[string]interface{}{
+				"metadata": []interface{}{map[string]interface{}{
+					"name":   "test",
+					"labels": map[string]interface{}{"team": "ml"},
+				}},
+			})
+			if err := resourceData.Set("metadata", []interface{}{map[string]interface{}{
+				"name":             "test",
+				"labels":           map[string]interface{}{"team": "ml"},
+				"resource_version": "1",
+			}}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			ops := patch.PatchOperations{&patch.AddOperation{Path: "/metadata/labels", Value: map[string]interface{}{"team": "ml"}}}
+
+			var resourceVersions []string
+			err := updateJob(context.Background(), cli, resourceData, "PyTorchJob", "default", "test", ops, func(data []byte) error {
+				for _, rv := range []string{"1", "2"} {
+					if strings.Contains(string(data), `"value":"`+rv+`","op":"test"`) {
+						resourceVersions = append(resourceVersions, rv)
+					}
+				}
+				if len(resourceVersions) == 1 {
+					return conflict
+				}
+				return nil
+			})
+
+			if tc.expectedErr == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedErr)) {
+				t.Fatalf("expected error mentioning %q, got %v", tc.expectedErr, err)
+			}
+			if strings.Join(resourceVersions, ",") != strings.Join(tc.expectedRVs, ",") {
+				t.Fatalf("expected updates at resourceVersions %v, got %v", tc.expectedRVs, resourceVersions)
+			}
+		})
+	}
+}
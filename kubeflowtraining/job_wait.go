@@ -0,0 +1,294 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+)
+
+// jobPendingStates are the non-terminal states a job passes through while
+// it is being created and run by the training operator.
+var jobPendingStates = []string{"Creating", "Created", "Suspended", "Pending", "Running", "Restarting"}
+
+// jobSuspended is the condition of a suspended job, e.g. one Kueue has not
+// admitted yet. The vendored kubeflow/common predates it.
+const jobSuspended commonv1.JobConditionType = "Suspended"
+
+// jobConditionsState maps the conditions of a training job to a single state.
+// Terminal conditions take precedence; otherwise the most recent true
+// condition wins, since the operator appends conditions in order.
+func jobConditionsState(conditions []commonv1.JobCondition) string {
+	for _, c := range conditions {
+		if c.Type == commonv1.JobSucceeded && c.Status == corev1.ConditionTrue {
+			return "Succeeded"
+		}
+		if c.Type == commonv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return "Failed"
+		}
+	}
+
+	for i := len(conditions) - 1; i >= 0; i-- {
+		c := conditions[i]
+		switch {
+		case c.Type == jobSuspended && c.Status == corev1.ConditionTrue:
+			return "Suspended"
+		case c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionTrue:
+			return "Restarting"
+		case c.Type == commonv1.JobRunning && c.Status == corev1.ConditionTrue:
+			return "Running"
+		case c.Type == commonv1.JobRunning && c.Status == corev1.ConditionFalse:
+			return "Pending"
+		case c.Type == commonv1.JobCreated && c.Status == corev1.ConditionTrue:
+			return "Created"
+		}
+	}
+
+	return "Creating"
+}
+
+// jobStateConf waits for a training job to reach one of the Target states,
+// in the spirit of resource.StateChangeConf. Instead of polling it is driven
+// by watch events on the job, so condition changes are observed as soon as
+// the apiserver publishes them. When the watch expires the job is re-read
+// once and the watch resumed from the fresh resourceVersion; when no watch can
+// be established at all it falls back to polling with Refresh.
+type jobStateConf struct {
+	Pending []string
+	Target  []string
+	Timeout time.Duration
+
+	// Refresh reads the job with a plain GET. A nil object means the job does
+	// not exist, which satisfies an empty Target (i.e. a deletion).
+	Refresh resource.StateRefreshFunc
+	// Watch opens a watch on the job starting after resourceVersion.
+	Watch func(resourceVersion string) (watch.Interface, error)
+	// State computes the state of a job object delivered by the watch.
+	State func(obj interface{}) (string, error)
+	// Progress, when set, is called with the latest job object on every state
+	// change and every jobProgressInterval while waiting.
+	Progress func(ctx context.Context, obj interface{}, state string)
+	// Describe, when set, explains in timeout errors why the job is still in
+	// state, e.g. that it is pending admission.
+	Describe func(ctx context.Context, obj interface{}, state string) string
+}
+
+// WaitForStateContext waits until the job reaches a target state, the timeout
+// elapses or ctx is done.
+func (conf *jobStateConf) WaitForStateContext(ctx context.Context) (interface{}, error) {
+	deadline := time.Now().Add(conf.Timeout)
+
+	obj, state, err := conf.Refresh()
+	if err != nil {
+		return obj, err
+	}
+
+	for {
+		done, err := conf.check(obj, state)
+		if done || err != nil {
+			return obj, err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return obj, conf.timeoutError(ctx, obj, state)
+		}
+
+		w, err := conf.Watch(resourceVersion(obj))
+		if err != nil {
+			log.Printf("[DEBUG] Unable to watch job, falling back to polling: %s", err)
+			return conf.poll(ctx, remaining)
+		}
+
+		var expired bool
+		obj, state, expired, err = conf.consume(ctx, w, obj, state, remaining)
+		w.Stop()
+		if err != nil {
+			return obj, err
+		}
+		if expired {
+			log.Printf("[DEBUG] Watch expired, re-reading job")
+			if obj, state, err = conf.Refresh(); err != nil {
+				return obj, err
+			}
+		}
+	}
+}
+
+// consume processes events from w until the job reaches a final state, the
+// watch is closed or expires, the timeout elapses or ctx is done.
+func (conf *jobStateConf) consume(ctx context.Context, w watch.Interface, obj interface{}, state string, timeout time.Duration) (interface{}, string, bool, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(jobProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			conf.progress(ctx, obj, state)
+		case <-timer.C:
+			return obj, state, false, conf.timeoutError(ctx, obj, state)
+		case <-ctx.Done():
+			return obj, state, false, fmt.Errorf("stopped waiting for state to become '%v' (last state: '%s'): %s", conf.Target, state, ctx.Err())
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				// The server closed the watch, resume from the last seen version.
+				return obj, state, false, nil
+			}
+
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				s, err := conf.State(event.Object)
+				if err != nil {
+					return event.Object, s, false, err
+				}
+				if s != state {
+					conf.progress(ctx, event.Object, s)
+				}
+				obj, state = event.Object, s
+			case watch.Deleted:
+				obj, state = nil, ""
+			case watch.Bookmark:
+				if rv := resourceVersion(event.Object); rv != "" && obj != nil {
+					if accessor, err := meta.Accessor(obj); err == nil {
+						accessor.SetResourceVersion(rv)
+					}
+				}
+				continue
+			case watch.Error:
+				err := errors.FromObject(event.Object)
+				if errors.IsResourceExpired(err) || errors.IsGone(err) {
+					return obj, state, true, nil
+				}
+				return obj, state, false, err
+			}
+
+			done, err := conf.check(obj, state)
+			if done || err != nil {
+				return obj, state, false, err
+			}
+		}
+	}
+}
+
+// poll waits for the job with resource.StateChangeConf, used when the job
+// cannot be watched.
+func (conf *jobStateConf) poll(ctx context.Context, timeout time.Duration) (interface{}, error) {
+	var lastProgress time.Time
+	var lastObj interface{}
+	var lastState string
+	stateConf := &resource.StateChangeConf{
+		Pending: conf.Pending,
+		Target:  conf.Target,
+		Timeout: timeout,
+		Refresh: func() (interface{}, string, error) {
+			obj, state, err := conf.Refresh()
+			if err == nil && time.Since(lastProgress) >= jobProgressInterval {
+				conf.progress(ctx, obj, state)
+				lastProgress = time.Now()
+			}
+			lastObj, lastState = obj, state
+			return obj, state, err
+		},
+	}
+	obj, err := stateConf.WaitForState()
+	if _, ok := err.(*resource.TimeoutError); ok {
+		if description := conf.describe(ctx, lastObj, lastState); description != "" {
+			err = fmt.Errorf("%s: %s", err, description)
+		}
+	}
+	return obj, err
+}
+
+// waitForJobPodsDeleted waits until no pod labelled with the job's name is
+// left, so that capacity held by terminating pods is free once it returns.
+func waitForJobPodsDeleted(ctx context.Context, cli client.Client, namespace string, name string, timeout time.Duration) error {
+	selector := labels.SelectorFromSet(labels.Set{commonv1.JobNameLabel: name}).String()
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Terminating"},
+		Target:  []string{"Deleted"},
+		Timeout: timeout,
+		Refresh: func() (interface{}, string, error) {
+			pods, err := cli.ListPods(ctx, namespace, selector)
+			if err != nil {
+				return nil, "", err
+			}
+			if len(pods) > 0 {
+				log.Printf("[DEBUG] %d pods of job %s are still terminating", len(pods), name)
+				return pods, "Terminating", nil
+			}
+			return pods, "Deleted", nil
+		},
+	}
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+func (conf *jobStateConf) progress(ctx context.Context, obj interface{}, state string) {
+	if conf.Progress != nil {
+		conf.Progress(ctx, obj, state)
+	}
+}
+
+// check reports whether obj in state is final. Reaching an unexpected state,
+// or losing the job while waiting for a target state, is an error.
+func (conf *jobStateConf) check(obj interface{}, state string) (bool, error) {
+	if obj == nil {
+		if len(conf.Target) == 0 {
+			return true, nil
+		}
+		if state == "" {
+			return false, fmt.Errorf("job was deleted while waiting for state %v", conf.Target)
+		}
+		return false, nil
+	}
+
+	for _, s := range conf.Target {
+		if s == state {
+			return true, nil
+		}
+	}
+	for _, s := range conf.Pending {
+		if s == state {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("unexpected state '%s', wanted target '%v'", state, conf.Target)
+}
+
+func (conf *jobStateConf) describe(ctx context.Context, obj interface{}, state string) string {
+	if conf.Describe == nil || obj == nil {
+		return ""
+	}
+	return conf.Describe(ctx, obj, state)
+}
+
+func (conf *jobStateConf) timeoutError(ctx context.Context, obj interface{}, state string) error {
+	err := fmt.Errorf("timeout while waiting for state to become '%v' (last state: '%s', timeout: %s)", conf.Target, state, conf.Timeout)
+	if description := conf.describe(ctx, obj, state); description != "" {
+		return fmt.Errorf("%s: %s", err, description)
+	}
+	return err
+}
+
+func resourceVersion(obj interface{}) string {
+	if obj == nil {
+		return ""
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return accessor.GetResourceVersion()
+}
@@ -0,0 +1,167 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func testPyTorchJob(resourceVersion string, conditions ...commonv1.JobConditionType) *kubeflowv1.PyTorchJob {
+	ptj := &kubeflowv1.PyTorchJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", ResourceVersion: resourceVersion},
+	}
+	for _, c := range conditions {
+		ptj.Status.Conditions = append(ptj.Status.Conditions, commonv1.JobCondition{Type: c, Status: corev1.ConditionTrue})
+	}
+	return ptj
+}
+
+func testJobStateConf(refresh func() *kubeflowv1.PyTorchJob, watches []*watch.FakeWatcher, resourceVersions *[]string) *jobStateConf {
+	return &jobStateConf{
+		Pending: jobPendingStates,
+		Target:  []string{"Succeeded"},
+		Timeout: 5 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			ptj := refresh()
+			return ptj, jobConditionsState(ptj.Status.Conditions), nil
+		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			*resourceVersions = append(*resourceVersions, resourceVersion)
+			w := watches[0]
+			watches = watches[1:]
+			return w, nil
+		},
+		State: func(obj interface{}) (string, error) {
+			return jobConditionsState(obj.(*kubeflowv1.PyTorchJob).Status.Conditions), nil
+		},
+	}
+}
+
+func TestJobConditionsState(t *testing.T) {
+	testCases := []struct {
+		Conditions []commonv1.JobConditionType
+		Expected   string
+	}{
+		{nil, "Creating"},
+		{[]commonv1.JobConditionType{commonv1.JobCreated}, "Created"},
+		{[]commonv1.JobConditionType{commonv1.JobCreated, commonv1.JobRunning}, "Running"},
+		{[]commonv1.JobConditionType{commonv1.JobCreated, commonv1.JobRunning, commonv1.JobRestarting}, "Restarting"},
+		{[]commonv1.JobConditionType{commonv1.JobCreated, commonv1.JobRunning, commonv1.JobSucceeded}, "Succeeded"},
+		{[]commonv1.JobConditionType{commonv1.JobCreated, commonv1.JobFailed, commonv1.JobRestarting}, "Failed"},
+		{[]commonv1.JobConditionType{commonv1.JobCreated, jobSuspended}, "Suspended"},
+	}
+
+	for i, tc := range testCases {
+		state := jobConditionsState(testPyTorchJob("", tc.Conditions...).Status.Conditions)
+		if state != tc.Expected {
+			t.Fatalf("%d: expected state %q, got %q", i, tc.Expected, state)
+		}
+	}
+}
+
+func TestJobStateConfWatch(t *testing.T) {
+	w := watch.NewFake()
+	var resourceVersions []string
+	conf := testJobStateConf(func() *kubeflowv1.PyTorchJob {
+		return testPyTorchJob("1", commonv1.JobCreated)
+	}, []*watch.FakeWatcher{w}, &resourceVersions)
+
+	go func() {
+		w.Modify(testPyTorchJob("2", commonv1.JobCreated, commonv1.JobRunning))
+		w.Modify(testPyTorchJob("3", commonv1.JobCreated, commonv1.JobRunning, commonv1.JobSucceeded))
+	}()
+
+	obj, err := conf.WaitForStateContext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rv := obj.(*kubeflowv1.PyTorchJob).ResourceVersion; rv != "3" {
+		t.Fatalf("expected final resourceVersion 3, got %q", rv)
+	}
+	if len(resourceVersions) != 1 || resourceVersions[0] != "1" {
+		t.Fatalf("expected a single watch from resourceVersion 1, got %v", resourceVersions)
+	}
+}
+
+func TestJobStateConfWatchExpired(t *testing.T) {
+	expired, resumed := watch.NewFake(), watch.NewFake()
+	var resourceVersions []string
+	reads := 0
+	conf := testJobStateConf(func() *kubeflowv1.PyTorchJob {
+		reads++
+		if reads == 1 {
+			return testPyTorchJob("1", commonv1.JobCreated)
+		}
+		return testPyTorchJob("10", commonv1.JobCreated, commonv1.JobRunning)
+	}, []*watch.FakeWatcher{expired, resumed}, &resourceVersions)
+
+	go func() {
+		expired.Error(&metav1.Status{Status: metav1.StatusFailure, Code: http.StatusGone, Reason: metav1.StatusReasonExpired})
+		resumed.Modify(testPyTorchJob("11", commonv1.JobCreated, commonv1.JobRunning, commonv1.JobSucceeded))
+	}()
+
+	if _, err := conf.WaitForStateContext(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if reads != 2 {
+		t.Fatalf("expected the job to be re-read once after expiry, got %d reads", reads)
+	}
+	if len(resourceVersions) != 2 || resourceVersions[1] != "10" {
+		t.Fatalf("expected the watch to resume from resourceVersion 10, got %v", resourceVersions)
+	}
+}
+
+func TestJobStateConfFailed(t *testing.T) {
+	w := watch.NewFake()
+	var resourceVersions []string
+	conf := testJobStateConf(func() *kubeflowv1.PyTorchJob {
+		return testPyTorchJob("1", commonv1.JobCreated)
+	}, []*watch.FakeWatcher{w}, &resourceVersions)
+
+	go w.Modify(testPyTorchJob("2", commonv1.JobCreated, commonv1.JobFailed))
+
+	if _, err := conf.WaitForStateContext(context.Background()); err == nil {
+		t.Fatal("expected an error for a failed job")
+	}
+}
+
+func TestJobWaitTargetReached(t *testing.T) {
+	running := testPyTorchJob("1", commonv1.JobCreated, commonv1.JobRunning)
+	running.Status.ReplicaStatuses = map[commonv1.ReplicaType]*commonv1.ReplicaStatus{
+		kubeflowv1.PyTorchJobReplicaTypeWorker: {Active: 2},
+	}
+
+	testCases := []struct {
+		Target   jobWaitTarget
+		Job      *kubeflowv1.PyTorchJob
+		Expected bool
+		Error    bool
+	}{
+		{jobWaitTarget{condition: "Created"}, testPyTorchJob("1"), false, false},
+		{jobWaitTarget{condition: "Created"}, running, true, false},
+		{jobWaitTarget{condition: "Running"}, testPyTorchJob("1", commonv1.JobCreated), false, false},
+		{jobWaitTarget{condition: "Running"}, testPyTorchJob("1", commonv1.JobCreated, commonv1.JobSucceeded), true, false},
+		{jobWaitTarget{condition: "Succeeded"}, testPyTorchJob("1", commonv1.JobCreated, commonv1.JobFailed), false, true},
+		{jobWaitTarget{jsonPath: ".status.replicaStatuses.Worker.active", value: "2"}, running, true, false},
+		{jobWaitTarget{jsonPath: "{.status.replicaStatuses.Worker.active}", value: "3"}, running, false, false},
+		{jobWaitTarget{jsonPath: ".status.replicaStatuses.Master"}, running, false, false},
+		{jobWaitTarget{jsonPath: ".status.replicaStatuses.Master"}, testPyTorchJob("1", commonv1.JobSucceeded), false, true},
+	}
+
+	for i, tc := range testCases {
+		reached, err := tc.Target.reached(tc.Job, jobConditionsState(tc.Job.Status.Conditions))
+		if (err != nil) != tc.Error {
+			t.Fatalf("%d: unexpected error: %v", i, err)
+		}
+		if reached != tc.Expected {
+			t.Fatalf("%d: expected reached to be %t, got %t", i, tc.Expected, reached)
+		}
+	}
+}
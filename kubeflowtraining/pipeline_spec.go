@@ -0,0 +1,98 @@
+package kubeflowtraining
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+// pipelineSpecSchema is the pipeline_spec block shared by runs and jobs.
+func pipelineSpecSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: description,
+		Required:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"pipeline_version_id": {
+					Type:         schema.TypeString,
+					Description:  "ID of the pipeline version to run.",
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"parameters": {
+					Type:        schema.TypeMap,
+					Description: "Values of the pipeline parameters.",
+					Optional:    true,
+					ForceNew:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// expandPipelineSpec returns the pipeline spec of pipeline_spec, and the
+// references to the pipeline version and to the experiment in experiment_id.
+func expandPipelineSpec(resourceData *schema.ResourceData) (*pipelines.PipelineSpec, []pipelines.ResourceReference) {
+	spec := resourceData.Get("pipeline_spec").([]interface{})[0].(map[string]interface{})
+
+	refs := []pipelines.ResourceReference{{
+		Key:          &pipelines.ResourceKey{Type: pipelines.ResourceTypePipelineVersion, ID: spec["pipeline_version_id"].(string)},
+		Relationship: pipelines.RelationshipCreator,
+	}}
+	if v, ok := resourceData.GetOk("experiment_id"); ok {
+		refs = append(refs, pipelines.ResourceReference{
+			Key:          &pipelines.ResourceKey{Type: pipelines.ResourceTypeExperiment, ID: v.(string)},
+			Relationship: pipelines.RelationshipOwner,
+		})
+	}
+
+	parameters := spec["parameters"].(map[string]interface{})
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := &pipelines.PipelineSpec{}
+	for _, name := range names {
+		out.Parameters = append(out.Parameters, pipelines.Parameter{
+			Name:  name,
+			Value: parameters[name].(string),
+		})
+	}
+	return out, refs
+}
+
+// flattenPipelineSpec returns pipeline_spec and the experiment ID of a run or
+// job.
+func flattenPipelineSpec(in *pipelines.PipelineSpec, refs []pipelines.ResourceReference) ([]interface{}, string) {
+	spec := map[string]interface{}{}
+	parameters := map[string]interface{}{}
+	if in != nil {
+		for _, p := range in.Parameters {
+			parameters[p.Name] = p.Value
+		}
+	}
+	spec["parameters"] = parameters
+
+	experimentID := ""
+	for _, ref := range refs {
+		if ref.Key == nil {
+			continue
+		}
+		switch ref.Key.Type {
+		case pipelines.ResourceTypePipelineVersion:
+			spec["pipeline_version_id"] = ref.Key.ID
+		case pipelines.ResourceTypeExperiment:
+			experimentID = ref.Key.ID
+		}
+	}
+	return []interface{}{spec}, experimentID
+}
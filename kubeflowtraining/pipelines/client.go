@@ -0,0 +1,428 @@
+package pipelines
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// apiPrefix is the path of the Kubeflow Pipelines v1beta1 REST API.
+const apiPrefix = "/apis/v1beta1"
+
+// ErrNoHost is returned by every call of a client without a host.
+var ErrNoHost = errors.New("the Kubeflow Pipelines API is not configured, set pipelines_host in the provider block")
+
+type Client interface {
+	// Pipelines and their versions
+	UploadPipeline(ctx context.Context, name string, description string, fileName string, file io.Reader) (*Pipeline, error)
+	CreatePipeline(ctx context.Context, pipeline *Pipeline) (*Pipeline, error)
+	GetPipeline(ctx context.Context, id string) (*Pipeline, error)
+	ListPipelines(ctx context.Context, opts ListOptions) (*ListPipelinesResponse, error)
+	DeletePipeline(ctx context.Context, id string) error
+	UploadPipelineVersion(ctx context.Context, pipelineID string, name string, fileName string, file io.Reader) (*PipelineVersion, error)
+	CreatePipelineVersion(ctx context.Context, version *PipelineVersion) (*PipelineVersion, error)
+	GetPipelineVersion(ctx context.Context, id string) (*PipelineVersion, error)
+	ListPipelineVersions(ctx context.Context, pipelineID string, opts ListOptions) (*ListPipelineVersionsResponse, error)
+	GetPipelineVersionTemplate(ctx context.Context, id string) (string, error)
+	DeletePipelineVersion(ctx context.Context, id string) error
+	UpdatePipelineDefaultVersion(ctx context.Context, pipelineID string, versionID string) error
+
+	// Experiments
+	CreateExperiment(ctx context.Context, experiment *Experiment) (*Experiment, error)
+	GetExperiment(ctx context.Context, id string) (*Experiment, error)
+	ListExperiments(ctx context.Context, opts ListOptions) (*ListExperimentsResponse, error)
+	DeleteExperiment(ctx context.Context, id string) error
+	ArchiveExperiment(ctx context.Context, id string) error
+	UnarchiveExperiment(ctx context.Context, id string) error
+
+	// Runs
+	CreateRun(ctx context.Context, run *Run) (*RunDetail, error)
+	GetRun(ctx context.Context, id string) (*RunDetail, error)
+	DeleteRun(ctx context.Context, id string) error
+	TerminateRun(ctx context.Context, id string) error
+
+	// Jobs, i.e. recurring runs
+	CreateJob(ctx context.Context, job *Job) (*Job, error)
+	GetJob(ctx context.Context, id string) (*Job, error)
+	DeleteJob(ctx context.Context, id string) error
+	EnableJob(ctx context.Context, id string) error
+	DisableJob(ctx context.Context, id string) error
+}
+
+type client struct {
+	host       string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the Kubeflow Pipelines API at host, e.g.
+// http://ml-pipeline-ui.kubeflow. A client without a host fails every call
+// with ErrNoHost, so that providers only managing training jobs need none.
+func NewClient(host string, httpClient *http.Client) Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &client{
+		host:       strings.TrimRight(host, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// APIError is an error response of the Kubeflow Pipelines API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Kubeflow Pipelines API returned %d: %s", e.StatusCode, e.Message)
+}
+
+// IsAlreadyExists reports whether err is a Kubeflow Pipelines API error for
+// an object whose name is taken.
+func IsAlreadyExists(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// IsNotFound reports whether err is a Kubeflow Pipelines API error for a
+// missing object.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// UploadPipeline implements Client
+func (c *client) UploadPipeline(ctx context.Context, name string, description string, fileName string, file io.Reader) (*Pipeline, error) {
+	query := url.Values{"name": {name}}
+	if description != "" {
+		query.Set("description", description)
+	}
+	var pipeline Pipeline
+	if err := c.upload(ctx, "/pipelines/upload", query, fileName, file, &pipeline); err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+// CreatePipeline implements Client
+func (c *client) CreatePipeline(ctx context.Context, pipeline *Pipeline) (*Pipeline, error) {
+	var out Pipeline
+	if err := c.do(ctx, http.MethodPost, "/pipelines", pipeline, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPipeline implements Client
+func (c *client) GetPipeline(ctx context.Context, id string) (*Pipeline, error) {
+	var pipeline Pipeline
+	if err := c.do(ctx, http.MethodGet, "/pipelines/"+url.PathEscape(id), nil, &pipeline); err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+// ListPipelines implements Client
+func (c *client) ListPipelines(ctx context.Context, opts ListOptions) (*ListPipelinesResponse, error) {
+	query, err := opts.query()
+	if err != nil {
+		return nil, err
+	}
+	var out ListPipelinesResponse
+	if err := c.do(ctx, http.MethodGet, "/pipelines?"+query.Encode(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeletePipeline implements Client
+func (c *client) DeletePipeline(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/pipelines/"+url.PathEscape(id), nil, nil)
+}
+
+// UploadPipelineVersion implements Client
+func (c *client) UploadPipelineVersion(ctx context.Context, pipelineID string, name string, fileName string, file io.Reader) (*PipelineVersion, error) {
+	query := url.Values{"name": {name}, "pipelineid": {pipelineID}}
+	var version PipelineVersion
+	if err := c.upload(ctx, "/pipelines/upload_version", query, fileName, file, &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// CreatePipelineVersion implements Client
+func (c *client) CreatePipelineVersion(ctx context.Context, version *PipelineVersion) (*PipelineVersion, error) {
+	var out PipelineVersion
+	if err := c.do(ctx, http.MethodPost, "/pipeline_versions", version, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPipelineVersion implements Client
+func (c *client) GetPipelineVersion(ctx context.Context, id string) (*PipelineVersion, error) {
+	var version PipelineVersion
+	if err := c.do(ctx, http.MethodGet, "/pipeline_versions/"+url.PathEscape(id), nil, &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// ListPipelineVersions implements Client
+func (c *client) ListPipelineVersions(ctx context.Context, pipelineID string, opts ListOptions) (*ListPipelineVersionsResponse, error) {
+	query, err := opts.query()
+	if err != nil {
+		return nil, err
+	}
+	query.Set("resource_key.type", ResourceTypePipeline)
+	query.Set("resource_key.id", pipelineID)
+	var out ListPipelineVersionsResponse
+	if err := c.do(ctx, http.MethodGet, "/pipeline_versions?"+query.Encode(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPipelineVersionTemplate implements Client
+func (c *client) GetPipelineVersionTemplate(ctx context.Context, id string) (string, error) {
+	var out struct {
+		Template string `json:"template"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/pipeline_versions/"+url.PathEscape(id)+"/templates", nil, &out); err != nil {
+		return "", err
+	}
+	return out.Template, nil
+}
+
+// DeletePipelineVersion implements Client
+func (c *client) DeletePipelineVersion(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/pipeline_versions/"+url.PathEscape(id), nil, nil)
+}
+
+// UpdatePipelineDefaultVersion implements Client
+func (c *client) UpdatePipelineDefaultVersion(ctx context.Context, pipelineID string, versionID string) error {
+	return c.do(ctx, http.MethodPost, "/pipelines/"+url.PathEscape(pipelineID)+"/default_version/"+url.PathEscape(versionID), nil, nil)
+}
+
+// CreateExperiment implements Client
+func (c *client) CreateExperiment(ctx context.Context, experiment *Experiment) (*Experiment, error) {
+	var out Experiment
+	if err := c.do(ctx, http.MethodPost, "/experiments", experiment, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetExperiment implements Client
+func (c *client) GetExperiment(ctx context.Context, id string) (*Experiment, error) {
+	var experiment Experiment
+	if err := c.do(ctx, http.MethodGet, "/experiments/"+url.PathEscape(id), nil, &experiment); err != nil {
+		return nil, err
+	}
+	return &experiment, nil
+}
+
+// ListExperiments implements Client
+func (c *client) ListExperiments(ctx context.Context, opts ListOptions) (*ListExperimentsResponse, error) {
+	query, err := opts.query()
+	if err != nil {
+		return nil, err
+	}
+	var out ListExperimentsResponse
+	if err := c.do(ctx, http.MethodGet, "/experiments?"+query.Encode(), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteExperiment implements Client
+func (c *client) DeleteExperiment(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/experiments/"+url.PathEscape(id), nil, nil)
+}
+
+// ArchiveExperiment implements Client
+func (c *client) ArchiveExperiment(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/experiments/"+url.PathEscape(id)+":archive", nil, nil)
+}
+
+// UnarchiveExperiment implements Client
+func (c *client) UnarchiveExperiment(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/experiments/"+url.PathEscape(id)+":unarchive", nil, nil)
+}
+
+// CreateRun implements Client
+func (c *client) CreateRun(ctx context.Context, run *Run) (*RunDetail, error) {
+	var out RunDetail
+	if err := c.do(ctx, http.MethodPost, "/runs", run, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRun implements Client
+func (c *client) GetRun(ctx context.Context, id string) (*RunDetail, error) {
+	var run RunDetail
+	if err := c.do(ctx, http.MethodGet, "/runs/"+url.PathEscape(id), nil, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// DeleteRun implements Client
+func (c *client) DeleteRun(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/runs/"+url.PathEscape(id), nil, nil)
+}
+
+// TerminateRun implements Client
+func (c *client) TerminateRun(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/runs/"+url.PathEscape(id)+"/terminate", nil, nil)
+}
+
+// CreateJob implements Client
+func (c *client) CreateJob(ctx context.Context, job *Job) (*Job, error) {
+	var out Job
+	if err := c.do(ctx, http.MethodPost, "/jobs", job, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetJob implements Client
+func (c *client) GetJob(ctx context.Context, id string) (*Job, error) {
+	var job Job
+	if err := c.do(ctx, http.MethodGet, "/jobs/"+url.PathEscape(id), nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// DeleteJob implements Client
+func (c *client) DeleteJob(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/jobs/"+url.PathEscape(id), nil, nil)
+}
+
+// EnableJob implements Client
+func (c *client) EnableJob(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/jobs/"+url.PathEscape(id)+"/enable", nil, nil)
+}
+
+// DisableJob implements Client
+func (c *client) DisableJob(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/jobs/"+url.PathEscape(id)+"/disable", nil, nil)
+}
+
+// query returns the query parameters of a list call. The filter is passed as
+// JSON, as the API expects.
+func (opts ListOptions) query() (url.Values, error) {
+	query := url.Values{}
+	if opts.PageToken != "" {
+		query.Set("page_token", opts.PageToken)
+	}
+	if opts.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(opts.PageSize))
+	}
+	if opts.SortBy != "" {
+		query.Set("sort_by", opts.SortBy)
+	}
+	if opts.Filter != nil {
+		filter, err := json.Marshal(opts.Filter)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("filter", string(filter))
+	}
+	return query, nil
+}
+
+// do sends in as JSON to the API path and decodes the response into out.
+func (c *client) do(ctx context.Context, method string, path string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	return c.send(ctx, method, path, "application/json", body, out)
+}
+
+// upload sends file as the uploadfile form field of a multipart request.
+func (c *client) upload(ctx context.Context, path string, query url.Values, fileName string, file io.Reader, out interface{}) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("uploadfile", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return c.send(ctx, http.MethodPost, path+"?"+query.Encode(), writer.FormDataContentType(), &body, out)
+}
+
+func (c *client) send(ctx context.Context, method string, path string, contentType string, body io.Reader, out interface{}) error {
+	if c.host == "" {
+		return ErrNoHost
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.host+apiPrefix+path, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	log.Printf("[DEBUG] Kubeflow Pipelines API request: %s %s", method, req.URL.Path)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{StatusCode: resp.StatusCode, Message: errorMessage(data)}
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// errorMessage returns the message of an API error body, which is a JSON
+// status with an error field, or the body itself.
+func errorMessage(data []byte) string {
+	var status struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &status); err == nil {
+		if status.Error != "" {
+			return status.Error
+		}
+		if status.Message != "" {
+			return status.Message
+		}
+	}
+	return strings.TrimSpace(string(data))
+}
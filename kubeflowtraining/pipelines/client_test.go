@@ -0,0 +1,61 @@
+package pipelines
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUploadPipeline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apis/v1beta1/pipelines/upload" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if v := r.URL.Query().Get("name"); v != "example" {
+			t.Fatalf("unexpected name %q", v)
+		}
+		file, header, err := r.FormFile("uploadfile")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		data, _ := io.ReadAll(file)
+		if header.Filename != "example.yaml" || string(data) != "kind: Workflow" {
+			t.Fatalf("unexpected upload %s: %q", header.Filename, data)
+		}
+		io.WriteString(w, `{"id": "p1", "name": "example", "default_version": {"id": "v1", "name": "example"}}`)
+	}))
+	defer server.Close()
+
+	pipeline, err := NewClient(server.URL, server.Client()).UploadPipeline(context.Background(), "example", "", "example.yaml", strings.NewReader("kind: Workflow"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pipeline.ID != "p1" || pipeline.DefaultVersion == nil || pipeline.DefaultVersion.ID != "v1" {
+		t.Fatalf("unexpected pipeline %+v", pipeline)
+	}
+}
+
+func TestGetPipelineNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error": "Pipeline p1 not found.", "code": 5}`)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, server.Client()).GetPipeline(context.Background(), "p1")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Pipeline p1 not found.") {
+		t.Fatalf("expected the API error message, got %q", err)
+	}
+}
+
+func TestClientWithoutHost(t *testing.T) {
+	if _, err := NewClient("", nil).GetPipeline(context.Background(), "p1"); err != ErrNoHost {
+		t.Fatalf("expected ErrNoHost, got %v", err)
+	}
+}
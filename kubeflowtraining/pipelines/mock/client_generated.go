@@ -0,0 +1,431 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./client.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pipelines "github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// ArchiveExperiment mocks base method.
+func (m *MockClient) ArchiveExperiment(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveExperiment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveExperiment indicates an expected call of ArchiveExperiment.
+func (mr *MockClientMockRecorder) ArchiveExperiment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveExperiment", reflect.TypeOf((*MockClient)(nil).ArchiveExperiment), ctx, id)
+}
+
+// CreateExperiment mocks base method.
+func (m *MockClient) CreateExperiment(ctx context.Context, experiment *pipelines.Experiment) (*pipelines.Experiment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExperiment", ctx, experiment)
+	ret0, _ := ret[0].(*pipelines.Experiment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExperiment indicates an expected call of CreateExperiment.
+func (mr *MockClientMockRecorder) CreateExperiment(ctx, experiment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExperiment", reflect.TypeOf((*MockClient)(nil).CreateExperiment), ctx, experiment)
+}
+
+// CreateJob mocks base method.
+func (m *MockClient) CreateJob(ctx context.Context, job *pipelines.Job) (*pipelines.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, job)
+	ret0, _ := ret[0].(*pipelines.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockClientMockRecorder) CreateJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockClient)(nil).CreateJob), ctx, job)
+}
+
+// CreatePipeline mocks base method.
+func (m *MockClient) CreatePipeline(ctx context.Context, pipeline *pipelines.Pipeline) (*pipelines.Pipeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePipeline", ctx, pipeline)
+	ret0, _ := ret[0].(*pipelines.Pipeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePipeline indicates an expected call of CreatePipeline.
+func (mr *MockClientMockRecorder) CreatePipeline(ctx, pipeline interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePipeline", reflect.TypeOf((*MockClient)(nil).CreatePipeline), ctx, pipeline)
+}
+
+// CreatePipelineVersion mocks base method.
+func (m *MockClient) CreatePipelineVersion(ctx context.Context, version *pipelines.PipelineVersion) (*pipelines.PipelineVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePipelineVersion", ctx, version)
+	ret0, _ := ret[0].(*pipelines.PipelineVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePipelineVersion indicates an expected call of CreatePipelineVersion.
+func (mr *MockClientMockRecorder) CreatePipelineVersion(ctx, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePipelineVersion", reflect.TypeOf((*MockClient)(nil).CreatePipelineVersion), ctx, version)
+}
+
+// CreateRun mocks base method.
+func (m *MockClient) CreateRun(ctx context.Context, run *pipelines.Run) (*pipelines.RunDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", ctx, run)
+	ret0, _ := ret[0].(*pipelines.RunDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockClientMockRecorder) CreateRun(ctx, run interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockClient)(nil).CreateRun), ctx, run)
+}
+
+// DeleteExperiment mocks base method.
+func (m *MockClient) DeleteExperiment(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExperiment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExperiment indicates an expected call of DeleteExperiment.
+func (mr *MockClientMockRecorder) DeleteExperiment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExperiment", reflect.TypeOf((*MockClient)(nil).DeleteExperiment), ctx, id)
+}
+
+// DeleteJob mocks base method.
+func (m *MockClient) DeleteJob(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJob", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteJob indicates an expected call of DeleteJob.
+func (mr *MockClientMockRecorder) DeleteJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJob", reflect.TypeOf((*MockClient)(nil).DeleteJob), ctx, id)
+}
+
+// DeletePipeline mocks base method.
+func (m *MockClient) DeletePipeline(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePipeline", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePipeline indicates an expected call of DeletePipeline.
+func (mr *MockClientMockRecorder) DeletePipeline(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePipeline", reflect.TypeOf((*MockClient)(nil).DeletePipeline), ctx, id)
+}
+
+// DeletePipelineVersion mocks base method.
+func (m *MockClient) DeletePipelineVersion(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePipelineVersion", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePipelineVersion indicates an expected call of DeletePipelineVersion.
+func (mr *MockClientMockRecorder) DeletePipelineVersion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePipelineVersion", reflect.TypeOf((*MockClient)(nil).DeletePipelineVersion), ctx, id)
+}
+
+// DeleteRun mocks base method.
+func (m *MockClient) DeleteRun(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRun", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRun indicates an expected call of DeleteRun.
+func (mr *MockClientMockRecorder) DeleteRun(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRun", reflect.TypeOf((*MockClient)(nil).DeleteRun), ctx, id)
+}
+
+// DisableJob mocks base method.
+func (m *MockClient) DisableJob(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableJob", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableJob indicates an expected call of DisableJob.
+func (mr *MockClientMockRecorder) DisableJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableJob", reflect.TypeOf((*MockClient)(nil).DisableJob), ctx, id)
+}
+
+// EnableJob mocks base method.
+func (m *MockClient) EnableJob(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableJob", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableJob indicates an expected call of EnableJob.
+func (mr *MockClientMockRecorder) EnableJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableJob", reflect.TypeOf((*MockClient)(nil).EnableJob), ctx, id)
+}
+
+// GetExperiment mocks base method.
+func (m *MockClient) GetExperiment(ctx context.Context, id string) (*pipelines.Experiment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExperiment", ctx, id)
+	ret0, _ := ret[0].(*pipelines.Experiment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExperiment indicates an expected call of GetExperiment.
+func (mr *MockClientMockRecorder) GetExperiment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExperiment", reflect.TypeOf((*MockClient)(nil).GetExperiment), ctx, id)
+}
+
+// GetJob mocks base method.
+func (m *MockClient) GetJob(ctx context.Context, id string) (*pipelines.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(*pipelines.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockClientMockRecorder) GetJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockClient)(nil).GetJob), ctx, id)
+}
+
+// GetPipeline mocks base method.
+func (m *MockClient) GetPipeline(ctx context.Context, id string) (*pipelines.Pipeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPipeline", ctx, id)
+	ret0, _ := ret[0].(*pipelines.Pipeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPipeline indicates an expected call of GetPipeline.
+func (mr *MockClientMockRecorder) GetPipeline(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipeline", reflect.TypeOf((*MockClient)(nil).GetPipeline), ctx, id)
+}
+
+// GetPipelineVersion mocks base method.
+func (m *MockClient) GetPipelineVersion(ctx context.Context, id string) (*pipelines.PipelineVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPipelineVersion", ctx, id)
+	ret0, _ := ret[0].(*pipelines.PipelineVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPipelineVersion indicates an expected call of GetPipelineVersion.
+func (mr *MockClientMockRecorder) GetPipelineVersion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipelineVersion", reflect.TypeOf((*MockClient)(nil).GetPipelineVersion), ctx, id)
+}
+
+// GetPipelineVersionTemplate mocks base method.
+func (m *MockClient) GetPipelineVersionTemplate(ctx context.Context, id string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPipelineVersionTemplate", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPipelineVersionTemplate indicates an expected call of GetPipelineVersionTemplate.
+func (mr *MockClientMockRecorder) GetPipelineVersionTemplate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipelineVersionTemplate", reflect.TypeOf((*MockClient)(nil).GetPipelineVersionTemplate), ctx, id)
+}
+
+// GetRun mocks base method.
+func (m *MockClient) GetRun(ctx context.Context, id string) (*pipelines.RunDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRun", ctx, id)
+	ret0, _ := ret[0].(*pipelines.RunDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRun indicates an expected call of GetRun.
+func (mr *MockClientMockRecorder) GetRun(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRun", reflect.TypeOf((*MockClient)(nil).GetRun), ctx, id)
+}
+
+// ListExperiments mocks base method.
+func (m *MockClient) ListExperiments(ctx context.Context, opts pipelines.ListOptions) (*pipelines.ListExperimentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExperiments", ctx, opts)
+	ret0, _ := ret[0].(*pipelines.ListExperimentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExperiments indicates an expected call of ListExperiments.
+func (mr *MockClientMockRecorder) ListExperiments(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExperiments", reflect.TypeOf((*MockClient)(nil).ListExperiments), ctx, opts)
+}
+
+// ListPipelineVersions mocks base method.
+func (m *MockClient) ListPipelineVersions(ctx context.Context, pipelineID string, opts pipelines.ListOptions) (*pipelines.ListPipelineVersionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPipelineVersions", ctx, pipelineID, opts)
+	ret0, _ := ret[0].(*pipelines.ListPipelineVersionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPipelineVersions indicates an expected call of ListPipelineVersions.
+func (mr *MockClientMockRecorder) ListPipelineVersions(ctx, pipelineID, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPipelineVersions", reflect.TypeOf((*MockClient)(nil).ListPipelineVersions), ctx, pipelineID, opts)
+}
+
+// ListPipelines mocks base method.
+func (m *MockClient) ListPipelines(ctx context.Context, opts pipelines.ListOptions) (*pipelines.ListPipelinesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPipelines", ctx, opts)
+	ret0, _ := ret[0].(*pipelines.ListPipelinesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPipelines indicates an expected call of ListPipelines.
+func (mr *MockClientMockRecorder) ListPipelines(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPipelines", reflect.TypeOf((*MockClient)(nil).ListPipelines), ctx, opts)
+}
+
+// TerminateRun mocks base method.
+func (m *MockClient) TerminateRun(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateRun", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TerminateRun indicates an expected call of TerminateRun.
+func (mr *MockClientMockRecorder) TerminateRun(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateRun", reflect.TypeOf((*MockClient)(nil).TerminateRun), ctx, id)
+}
+
+// UnarchiveExperiment mocks base method.
+func (m *MockClient) UnarchiveExperiment(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveExperiment", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnarchiveExperiment indicates an expected call of UnarchiveExperiment.
+func (mr *MockClientMockRecorder) UnarchiveExperiment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveExperiment", reflect.TypeOf((*MockClient)(nil).UnarchiveExperiment), ctx, id)
+}
+
+// UpdatePipelineDefaultVersion mocks base method.
+func (m *MockClient) UpdatePipelineDefaultVersion(ctx context.Context, pipelineID, versionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePipelineDefaultVersion", ctx, pipelineID, versionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePipelineDefaultVersion indicates an expected call of UpdatePipelineDefaultVersion.
+func (mr *MockClientMockRecorder) UpdatePipelineDefaultVersion(ctx, pipelineID, versionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineDefaultVersion", reflect.TypeOf((*MockClient)(nil).UpdatePipelineDefaultVersion), ctx, pipelineID, versionID)
+}
+
+// UploadPipeline mocks base method.
+func (m *MockClient) UploadPipeline(ctx context.Context, name, description, fileName string, file io.Reader) (*pipelines.Pipeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadPipeline", ctx, name, description, fileName, file)
+	ret0, _ := ret[0].(*pipelines.Pipeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPipeline indicates an expected call of UploadPipeline.
+func (mr *MockClientMockRecorder) UploadPipeline(ctx, name, description, fileName, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPipeline", reflect.TypeOf((*MockClient)(nil).UploadPipeline), ctx, name, description, fileName, file)
+}
+
+// UploadPipelineVersion mocks base method.
+func (m *MockClient) UploadPipelineVersion(ctx context.Context, pipelineID, name, fileName string, file io.Reader) (*pipelines.PipelineVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadPipelineVersion", ctx, pipelineID, name, fileName, file)
+	ret0, _ := ret[0].(*pipelines.PipelineVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPipelineVersion indicates an expected call of UploadPipelineVersion.
+func (mr *MockClientMockRecorder) UploadPipelineVersion(ctx, pipelineID, name, fileName, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPipelineVersion", reflect.TypeOf((*MockClient)(nil).UploadPipelineVersion), ctx, pipelineID, name, fileName, file)
+}
@@ -0,0 +1,201 @@
+package pipelines
+
+import (
+	"time"
+)
+
+// The types below mirror the JSON of the Kubeflow Pipelines v1beta1 REST API.
+
+type URL struct {
+	PipelineURL string `json:"pipeline_url,omitempty"`
+}
+
+type ResourceKey struct {
+	Type string `json:"type,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+type ResourceReference struct {
+	Key          *ResourceKey `json:"key,omitempty"`
+	Name         string       `json:"name,omitempty"`
+	Relationship string       `json:"relationship,omitempty"`
+}
+
+const (
+	ResourceTypeExperiment      = "EXPERIMENT"
+	ResourceTypePipeline        = "PIPELINE"
+	ResourceTypePipelineVersion = "PIPELINE_VERSION"
+
+	RelationshipOwner   = "OWNER"
+	RelationshipCreator = "CREATOR"
+)
+
+type Parameter struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+type Pipeline struct {
+	ID             string           `json:"id,omitempty"`
+	CreatedAt      *time.Time       `json:"created_at,omitempty"`
+	Name           string           `json:"name,omitempty"`
+	Description    string           `json:"description,omitempty"`
+	Parameters     []Parameter      `json:"parameters,omitempty"`
+	URL            *URL             `json:"url,omitempty"`
+	Error          string           `json:"error,omitempty"`
+	DefaultVersion *PipelineVersion `json:"default_version,omitempty"`
+}
+
+type ListPipelinesResponse struct {
+	Pipelines     []Pipeline `json:"pipelines,omitempty"`
+	TotalSize     int        `json:"total_size,omitempty"`
+	NextPageToken string     `json:"next_page_token,omitempty"`
+}
+
+type PipelineVersion struct {
+	ID                 string              `json:"id,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	CreatedAt          *time.Time          `json:"created_at,omitempty"`
+	Parameters         []Parameter         `json:"parameters,omitempty"`
+	CodeSourceURL      string              `json:"code_source_url,omitempty"`
+	PackageURL         *URL                `json:"package_url,omitempty"`
+	ResourceReferences []ResourceReference `json:"resource_references,omitempty"`
+	Description        string              `json:"description,omitempty"`
+}
+
+type ListPipelineVersionsResponse struct {
+	Versions      []PipelineVersion `json:"versions,omitempty"`
+	TotalSize     int               `json:"total_size,omitempty"`
+	NextPageToken string            `json:"next_page_token,omitempty"`
+}
+
+const (
+	StorageStateAvailable = "STORAGESTATE_AVAILABLE"
+	StorageStateArchived  = "STORAGESTATE_ARCHIVED"
+)
+
+type Experiment struct {
+	ID                 string              `json:"id,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	Description        string              `json:"description,omitempty"`
+	CreatedAt          *time.Time          `json:"created_at,omitempty"`
+	ResourceReferences []ResourceReference `json:"resource_references,omitempty"`
+	StorageState       string              `json:"storage_state,omitempty"`
+}
+
+type ListExperimentsResponse struct {
+	Experiments   []Experiment `json:"experiments,omitempty"`
+	TotalSize     int          `json:"total_size,omitempty"`
+	NextPageToken string       `json:"next_page_token,omitempty"`
+}
+
+type PipelineSpec struct {
+	PipelineID       string      `json:"pipeline_id,omitempty"`
+	PipelineName     string      `json:"pipeline_name,omitempty"`
+	WorkflowManifest string      `json:"workflow_manifest,omitempty"`
+	PipelineManifest string      `json:"pipeline_manifest,omitempty"`
+	Parameters       []Parameter `json:"parameters,omitempty"`
+}
+
+type RunMetric struct {
+	Name        string  `json:"name,omitempty"`
+	NodeID      string  `json:"node_id,omitempty"`
+	NumberValue float64 `json:"number_value,omitempty"`
+	Format      string  `json:"format,omitempty"`
+}
+
+// Run statuses in which a run no longer changes.
+const (
+	RunStatusSucceeded  = "Succeeded"
+	RunStatusFailed     = "Failed"
+	RunStatusError      = "Error"
+	RunStatusSkipped    = "Skipped"
+	RunStatusTerminated = "Terminated"
+)
+
+type Run struct {
+	ID                 string              `json:"id,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	StorageState       string              `json:"storage_state,omitempty"`
+	Description        string              `json:"description,omitempty"`
+	PipelineSpec       *PipelineSpec       `json:"pipeline_spec,omitempty"`
+	ResourceReferences []ResourceReference `json:"resource_references,omitempty"`
+	ServiceAccount     string              `json:"service_account,omitempty"`
+	CreatedAt          *time.Time          `json:"created_at,omitempty"`
+	ScheduledAt        *time.Time          `json:"scheduled_at,omitempty"`
+	FinishedAt         *time.Time          `json:"finished_at,omitempty"`
+	Status             string              `json:"status,omitempty"`
+	Error              string              `json:"error,omitempty"`
+	Metrics            []RunMetric         `json:"metrics,omitempty"`
+}
+
+type PipelineRuntime struct {
+	PipelineManifest string `json:"pipeline_manifest,omitempty"`
+	WorkflowManifest string `json:"workflow_manifest,omitempty"`
+}
+
+type RunDetail struct {
+	Run             *Run             `json:"run,omitempty"`
+	PipelineRuntime *PipelineRuntime `json:"pipeline_runtime,omitempty"`
+}
+
+const PredicateEquals = "EQUALS"
+
+type Predicate struct {
+	Op          string `json:"op,omitempty"`
+	Key         string `json:"key,omitempty"`
+	StringValue string `json:"string_value,omitempty"`
+}
+
+type Filter struct {
+	Predicates []Predicate `json:"predicates,omitempty"`
+}
+
+// ListOptions selects a page of a list call. SortBy is a field name,
+// optionally followed by " desc".
+type ListOptions struct {
+	PageToken string
+	PageSize  int
+	SortBy    string
+	Filter    *Filter
+}
+
+const (
+	JobModeEnabled  = "ENABLED"
+	JobModeDisabled = "DISABLED"
+)
+
+type CronSchedule struct {
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	Cron      string     `json:"cron,omitempty"`
+}
+
+type PeriodicSchedule struct {
+	StartTime      *time.Time `json:"start_time,omitempty"`
+	EndTime        *time.Time `json:"end_time,omitempty"`
+	IntervalSecond int64      `json:"interval_second,string,omitempty"`
+}
+
+type Trigger struct {
+	CronSchedule     *CronSchedule     `json:"cron_schedule,omitempty"`
+	PeriodicSchedule *PeriodicSchedule `json:"periodic_schedule,omitempty"`
+}
+
+type Job struct {
+	ID                 string              `json:"id,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	Description        string              `json:"description,omitempty"`
+	PipelineSpec       *PipelineSpec       `json:"pipeline_spec,omitempty"`
+	ResourceReferences []ResourceReference `json:"resource_references,omitempty"`
+	ServiceAccount     string              `json:"service_account,omitempty"`
+	MaxConcurrency     int64               `json:"max_concurrency,string,omitempty"`
+	Trigger            *Trigger            `json:"trigger,omitempty"`
+	Mode               string              `json:"mode,omitempty"`
+	CreatedAt          *time.Time          `json:"created_at,omitempty"`
+	UpdatedAt          *time.Time          `json:"updated_at,omitempty"`
+	Status             string              `json:"status,omitempty"`
+	Error              string              `json:"error,omitempty"`
+	Enabled            bool                `json:"enabled,omitempty"`
+	NoCatchup          bool                `json:"no_catchup,omitempty"`
+}
@@ -2,14 +2,18 @@ package kubeflowtraining
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/mitchellh/go-homedir"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -34,6 +38,7 @@ func Provider() terraform.ResourceProvider {
 			"password": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_PASSWORD", ""),
 				Description: "The password to use for HTTP basic authentication when accessing the Kubernetes master endpoint.",
 			},
@@ -46,12 +51,14 @@ func Provider() terraform.ResourceProvider {
 			"client_certificate": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_CLIENT_CERT_DATA", ""),
 				Description: "PEM-encoded client certificate for TLS authentication.",
 			},
 			"client_key": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_CLIENT_KEY_DATA", ""),
 				Description: "PEM-encoded client certificate key for TLS authentication.",
 			},
@@ -92,6 +99,7 @@ func Provider() terraform.ResourceProvider {
 			"token": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_TOKEN", ""),
 				Description: "Token to authentifcate an service account",
 			},
@@ -101,13 +109,51 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_LOAD_CONFIG_FILE", true),
 				Description: "Load local kubeconfig.",
 			},
+			"informer_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBEFLOW_INFORMER_CACHE", false),
+				Description: "Serve job reads from shared informers (one list and watch per kind and namespace) instead of one GET per job. Useful when refreshing many jobs.",
+			},
+			"informer_cache_max_staleness": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KUBEFLOW_INFORMER_CACHE_MAX_STALENESS", "30s"),
+				ValidateFunc: utils.ValidateDuration,
+				Description:  "Maximum age of the informer cache before reads fall back to the Kubernetes API, e.g. `30s`.",
+			},
+			"pipelines_host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBEFLOWPIPELINES_HOST", ""),
+				Description: "The URI of the Kubeflow Pipelines API, e.g. `http://ml-pipeline-ui.kubeflow`. Required by the kubeflowpipelines_* resources.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"kubeflow_pytorch_job": resourceKubeFlowPyTorchJob(),
-			"kubeflow_mpi_job":     resourceKubeFlowMPIJob(),
-			"kubeflow_xgboost_job": resourceKubeFlowXGBoostJob(),
-			"kubeflow_paddle_job":  resourceKubeFlowPaddleJob(),
-			"kubeflow_tf_job":      resourceKubeFlowTFJob(),
+			"kubeflow_pytorch_job":           resourceKubeFlowPyTorchJob(),
+			"kubeflow_mpi_job":               resourceKubeFlowMPIJob(),
+			"kubeflow_xgboost_job":           resourceKubeFlowXGBoostJob(),
+			"kubeflow_paddle_job":            resourceKubeFlowPaddleJob(),
+			"kubeflow_tf_job":                resourceKubeFlowTFJob(),
+			"kubeflow_job_wait":              resourceKubeFlowJobWait(),
+			"kubeflow_volcano_queue":         resourceKubeFlowVolcanoQueue(),
+			"kubeflow_kueue_resource_flavor": resourceKubeFlowKueueResourceFlavor(),
+			"kubeflow_kueue_cluster_queue":   resourceKubeFlowKueueClusterQueue(),
+			"kubeflow_kueue_local_queue":     resourceKubeFlowKueueLocalQueue(),
+
+			"kubeflowpipelines_pipeline":   resourceKubeFlowPipelinesPipeline(),
+			"kubeflowpipelines_experiment": resourceKubeFlowPipelinesExperiment(),
+			"kubeflowpipelines_run":        resourceKubeFlowPipelinesRun(),
+			"kubeflowpipelines_job":        resourceKubeFlowPipelinesJob(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"kubeflow_job_pods":         dataSourceKubeFlowJobPods(),
+			"kubeflow_job_logs":         dataSourceKubeFlowJobLogs(),
+			"kubeflow_job_manifest":     dataSourceKubeFlowJobManifest(),
+			"kubeflow_cluster_capacity": dataSourceKubeFlowClusterCapacity(),
+
+			"kubeflowpipelines_pipeline":   dataSourceKubeFlowPipelinesPipeline(),
+			"kubeflowpipelines_experiment": dataSourceKubeFlowPipelinesExperiment(),
 		},
 	}
 	p.ConfigureFunc = func(resourceData *schema.ResourceData) (interface{}, error) {
@@ -117,12 +163,41 @@ func Provider() terraform.ResourceProvider {
 			// We can therefore assume that if it's missing it's 0.10 or 0.11
 			terraformVersion = "0.11+compatible"
 		}
-		return providerConfigure(resourceData, terraformVersion)
+		return providerConfigure(resourceData, terraformVersion, p.StopContext())
 	}
 	return p
 }
 
-func providerConfigure(resourceData *schema.ResourceData, terraformVersion string) (interface{}, error) {
+// providerMeta is the meta value handed to resources. It embeds the
+// Kubernetes client, so resources keep asserting meta to client.Client, and
+// carries the Kubeflow Pipelines client and the provider's stop context,
+// which Terraform cancels on interrupt.
+type providerMeta struct {
+	client.Client
+	pipelines   pipelines.Client
+	stopContext context.Context
+}
+
+// pipelinesClient returns the Kubeflow Pipelines client of meta.
+func pipelinesClient(meta interface{}) pipelines.Client {
+	if m, ok := meta.(*providerMeta); ok && m.pipelines != nil {
+		return m.pipelines
+	}
+	return pipelines.NewClient("", nil)
+}
+
+// resourceContext returns the context for a resource operation. It expires
+// with the operation's Terraform timeout and is cancelled when Terraform asks
+// the provider to stop.
+func resourceContext(resourceData *schema.ResourceData, meta interface{}, timeoutKey string) (context.Context, context.CancelFunc) {
+	parent := context.Background()
+	if m, ok := meta.(*providerMeta); ok && m.stopContext != nil {
+		parent = m.stopContext
+	}
+	return context.WithTimeout(parent, resourceData.Timeout(timeoutKey))
+}
+
+func providerConfigure(resourceData *schema.ResourceData, terraformVersion string, stopContext context.Context) (interface{}, error) {
 
 	var cfg *restclient.Config
 	var err error
@@ -166,7 +241,26 @@ func providerConfigure(resourceData *schema.ResourceData, terraformVersion strin
 		cfg.BearerToken = v.(string)
 	}
 
-	return client.NewClient(cfg)
+	var cli client.Client
+	if resourceData.Get("informer_cache").(bool) {
+		var maxStaleness time.Duration
+		maxStaleness, err = time.ParseDuration(resourceData.Get("informer_cache_max_staleness").(string))
+		if err != nil {
+			return nil, err
+		}
+		cli, err = client.NewClientWithCache(cfg, maxStaleness)
+	} else {
+		cli, err = client.NewClient(cfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &providerMeta{
+		Client:      cli,
+		pipelines:   pipelines.NewClient(resourceData.Get("pipelines_host").(string), nil),
+		stopContext: stopContext,
+	}, nil
 }
 
 func tryLoadingConfigFile(resourceData *schema.ResourceData) (*restclient.Config, error) {
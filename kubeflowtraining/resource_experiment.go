@@ -0,0 +1,184 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+func resourceKubeFlowPipelinesExperiment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubeFlowPipelinesExperimentCreate,
+		Read:   resourceKubeFlowPipelinesExperimentRead,
+		Update: resourceKubeFlowPipelinesExperimentUpdate,
+		Delete: resourceKubeFlowPipelinesExperimentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the experiment.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the experiment.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"archive_on_destroy": {
+				Type:        schema.TypeBool,
+				Description: "Archive the experiment on destroy instead of deleting it, which keeps its runs. An archived experiment of the same name is unarchived and taken over on create.",
+				Optional:    true,
+				Default:     true,
+			},
+			"storage_state": {
+				Type:        schema.TypeString,
+				Description: "Storage state of the experiment, STORAGESTATE_AVAILABLE or STORAGESTATE_ARCHIVED.",
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "The date and time of creation, formatted with RFC3339.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubeFlowPipelinesExperimentCreate(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	name := resourceData.Get("name").(string)
+	log.Printf("[INFO] Creating experiment %s", name)
+	experiment, err := cli.CreateExperiment(ctx, &pipelines.Experiment{
+		Name:        name,
+		Description: resourceData.Get("description").(string),
+	})
+	if err != nil {
+		if !pipelines.IsAlreadyExists(err) {
+			return fmt.Errorf("unable to create experiment %s: %s", name, err)
+		}
+		// The name of an experiment archived on destroy is still taken.
+		experiment, err = archivedExperiment(ctx, cli, name)
+		if err != nil {
+			return err
+		}
+		if experiment == nil {
+			return fmt.Errorf("unable to create experiment %s: an experiment of that name already exists", name)
+		}
+		log.Printf("[INFO] Unarchiving experiment %s (%s)", name, experiment.ID)
+		if err := cli.UnarchiveExperiment(ctx, experiment.ID); err != nil {
+			return fmt.Errorf("unable to unarchive experiment %s: %s", name, err)
+		}
+	}
+	resourceData.SetId(experiment.ID)
+
+	return resourceKubeFlowPipelinesExperimentRead(resourceData, meta)
+}
+
+func resourceKubeFlowPipelinesExperimentRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	log.Printf("[INFO] Reading experiment %s", resourceData.Id())
+	experiment, err := cli.GetExperiment(ctx, resourceData.Id())
+	if err != nil {
+		if pipelines.IsNotFound(err) {
+			log.Printf("[DEBUG] Experiment %s no longer exists", resourceData.Id())
+			resourceData.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	if err := resourceData.Set("name", experiment.Name); err != nil {
+		return err
+	}
+	if err := resourceData.Set("description", experiment.Description); err != nil {
+		return err
+	}
+	if err := resourceData.Set("storage_state", experiment.StorageState); err != nil {
+		return err
+	}
+	if experiment.CreatedAt != nil {
+		return resourceData.Set("created_at", experiment.CreatedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func resourceKubeFlowPipelinesExperimentUpdate(resourceData *schema.ResourceData, meta interface{}) error {
+	// Only archive_on_destroy can change in place, which is read on destroy.
+	return resourceKubeFlowPipelinesExperimentRead(resourceData, meta)
+}
+
+func resourceKubeFlowPipelinesExperimentDelete(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	var err error
+	if resourceData.Get("archive_on_destroy").(bool) {
+		log.Printf("[INFO] Archiving experiment %s", resourceData.Id())
+		err = cli.ArchiveExperiment(ctx, resourceData.Id())
+	} else {
+		log.Printf("[INFO] Deleting experiment %s", resourceData.Id())
+		err = cli.DeleteExperiment(ctx, resourceData.Id())
+	}
+	if err != nil && !pipelines.IsNotFound(err) {
+		return err
+	}
+	resourceData.SetId("")
+	return nil
+}
+
+// archivedExperiment returns the archived experiment called name, or nil if
+// there is none.
+func archivedExperiment(ctx context.Context, cli pipelines.Client, name string) (*pipelines.Experiment, error) {
+	return findExperiment(ctx, cli, name, func(experiment *pipelines.Experiment) bool {
+		return experiment.StorageState == pipelines.StorageStateArchived
+	})
+}
+
+// findExperiment pages through the experiments called name and returns the
+// first one accepted by match, or nil if there is none.
+func findExperiment(ctx context.Context, cli pipelines.Client, name string, match func(*pipelines.Experiment) bool) (*pipelines.Experiment, error) {
+	opts := pipelines.ListOptions{Filter: nameFilter(name)}
+	for {
+		resp, err := cli.ListExperiments(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list experiments: %s", err)
+		}
+		for i := range resp.Experiments {
+			experiment := resp.Experiments[i]
+			if experiment.Name == name && match(&experiment) {
+				return &experiment, nil
+			}
+		}
+		if resp.NextPageToken == "" {
+			return nil, nil
+		}
+		opts.PageToken = resp.NextPageToken
+	}
+}
+
+// nameFilter filters a list call by name.
+func nameFilter(name string) *pipelines.Filter {
+	return &pipelines.Filter{Predicates: []pipelines.Predicate{{
+		Op:          pipelines.PredicateEquals,
+		Key:         "name",
+		StringValue: name,
+	}}}
+}
@@ -0,0 +1,71 @@
+package kubeflowtraining
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+func TestResourceExperimentUnarchivesOnCreate(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /apis/v1beta1/experiments":
+			w.WriteHeader(http.StatusConflict)
+			io.WriteString(w, `{"error": "Experiment example already exists.", "code": 6}`)
+		case "GET /apis/v1beta1/experiments":
+			if v := r.URL.Query().Get("filter"); v != `{"predicates":[{"op":"EQUALS","key":"name","string_value":"example"}]}` {
+				t.Errorf("unexpected filter %s", v)
+			}
+			io.WriteString(w, `{"experiments": [{"id": "e1", "name": "example", "storage_state": "STORAGESTATE_ARCHIVED"}]}`)
+		case "POST /apis/v1beta1/experiments/e1:unarchive":
+			io.WriteString(w, `{}`)
+		case "GET /apis/v1beta1/experiments/e1":
+			io.WriteString(w, `{"id": "e1", "name": "example", "storage_state": "STORAGESTATE_AVAILABLE"}`)
+		case "POST /apis/v1beta1/experiments/e1:archive":
+			io.WriteString(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta := &providerMeta{pipelines: pipelines.NewClient(server.URL, server.Client())}
+	resourceData := schema.TestResourceDataRaw(t, resourceKubeFlowPipelinesExperiment().Schema, map[string]interface{}{
+		"name": "example",
+	})
+	if err := resourceKubeFlowPipelinesExperimentCreate(resourceData, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resourceData.Id() != "e1" {
+		t.Fatalf("expected id e1, got %q", resourceData.Id())
+	}
+	if v := resourceData.Get("storage_state").(string); v != pipelines.StorageStateAvailable {
+		t.Fatalf("unexpected storage_state %q", v)
+	}
+	if err := resourceKubeFlowPipelinesExperimentDelete(resourceData, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"POST /apis/v1beta1/experiments",
+		"GET /apis/v1beta1/experiments",
+		"POST /apis/v1beta1/experiments/e1:unarchive",
+		"GET /apis/v1beta1/experiments/e1",
+		"POST /apis/v1beta1/experiments/e1:archive",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("expected requests %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Fatalf("expected requests %v, got %v", expected, requests)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package kubeflowtraining
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
+)
+
+// jobWaitConditions maps each condition kubeflow_job_wait can wait for to the
+// job states in which the job has reached it.
+var jobWaitConditions = map[string][]string{
+	"Created":   {"Created", "Pending", "Running", "Restarting", "Succeeded"},
+	"Running":   {"Running", "Succeeded"},
+	"Succeeded": {"Succeeded"},
+}
+
+func resourceKubeFlowJobWait() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubeFlowJobWaitCreate,
+		Read:   resourceKubeFlowJobWaitRead,
+		Delete: resourceKubeFlowJobWaitDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(40 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:         schema.TypeString,
+				Description:  "Kind of the job to wait for.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(jobKindNames(), false),
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the job to wait for.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "default",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the job to wait for. The job does not have to exist yet.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: utils.ValidateName,
+			},
+			"condition": {
+				Type:          schema.TypeString,
+				Description:   "Condition to wait for, one of Created, Running or Succeeded. Defaults to Succeeded unless jsonpath is set.",
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.StringInSlice([]string{"Created", "Running", "Succeeded"}, false),
+				ConflictsWith: []string{"jsonpath"},
+			},
+			"jsonpath": {
+				Type:          schema.TypeString,
+				Description:   "JSONPath expression evaluated against the job, e.g. `.status.replicaStatuses.Worker.succeeded`. The wait is over once it yields jsonpath_value, or anything when jsonpath_value is not set.",
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  utils.ValidateJSONPath,
+				ConflictsWith: []string{"condition"},
+			},
+			"jsonpath_value": {
+				Type:        schema.TypeString,
+				Description: "Value the jsonpath expression must yield.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Description: "State of the job when it was last read.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// jobWaitTarget is what a kubeflow_job_wait waits for: either a condition or
+// a JSONPath predicate.
+type jobWaitTarget struct {
+	condition string
+	jsonPath  string
+	value     string
+}
+
+func expandJobWaitTarget(resourceData *schema.ResourceData) jobWaitTarget {
+	target := jobWaitTarget{
+		condition: resourceData.Get("condition").(string),
+		jsonPath:  resourceData.Get("jsonpath").(string),
+		value:     resourceData.Get("jsonpath_value").(string),
+	}
+	if target.condition == "" && target.jsonPath == "" {
+		target.condition = "Succeeded"
+	}
+	return target
+}
+
+func (t jobWaitTarget) String() string {
+	if t.jsonPath != "" {
+		return fmt.Sprintf("%s == %q", t.jsonPath, t.value)
+	}
+	return t.condition
+}
+
+// reached reports whether a job in state satisfies the target. A failed job,
+// or one that succeeded without satisfying the target, never will.
+func (t jobWaitTarget) reached(obj interface{}, state string) (bool, error) {
+	if state == "Failed" {
+		return false, fmt.Errorf("job failed while waiting for %s", t)
+	}
+
+	var reached bool
+	if t.jsonPath != "" {
+		matches, err := jsonPathMatches(t.jsonPath, t.value, obj)
+		if err != nil {
+			return false, err
+		}
+		reached = matches
+	} else {
+		for _, s := range jobWaitConditions[t.condition] {
+			if s == state {
+				reached = true
+			}
+		}
+	}
+
+	if !reached && state == "Succeeded" {
+		return false, fmt.Errorf("job succeeded without reaching %s", t)
+	}
+	return reached, nil
+}
+
+// jsonPathMatches evaluates expr against obj and reports whether it yields
+// value, or anything at all when value is empty.
+func jsonPathMatches(expr string, value string, obj interface{}) (bool, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return false, err
+	}
+
+	jp := jsonpath.New("jsonpath").AllowMissingKeys(true)
+	if err := jp.Parse(utils.RelaxedJSONPath(expr)); err != nil {
+		return false, err
+	}
+	var out bytes.Buffer
+	if err := jp.Execute(&out, u); err != nil {
+		return false, err
+	}
+
+	if value == "" {
+		return out.Len() > 0, nil
+	}
+	return out.String() == value, nil
+}
+
+func resourceKubeFlowJobWaitCreate(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	kindName := resourceData.Get("kind").(string)
+	kind := jobKinds[kindName]
+	namespace := resourceData.Get("namespace").(string)
+	name := resourceData.Get("name").(string)
+	target := expandJobWaitTarget(resourceData)
+
+	state := func(obj interface{}) (string, error) {
+		s := kind.state(obj)
+		reached, err := target.reached(obj, s)
+		if err != nil {
+			return s, fmt.Errorf("%s %s/%s: %s", kindName, namespace, name, err)
+		}
+		if reached {
+			return "Reached", nil
+		}
+		return s, nil
+	}
+
+	log.Printf("[INFO] Waiting for %s %s/%s to reach %s", kindName, namespace, name, target)
+	progress := newJobProgress(cli, kindName, namespace, name)
+	stateConf := &jobStateConf{
+		Pending: jobPendingStates,
+		Target:  []string{"Reached"},
+		Timeout: resourceData.Timeout(schema.TimeoutCreate),
+		Refresh: func() (interface{}, string, error) {
+			obj, err := kind.get(ctx, cli, namespace, name)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					log.Printf("[DEBUG] %s %s is not created yet", kindName, name)
+					return nil, "Creating", nil
+				}
+				return nil, "", err
+			}
+			s, err := state(obj)
+			return obj, s, err
+		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return kind.watch(ctx, cli, namespace, name, resourceVersion)
+		},
+		State: state,
+		Progress: func(ctx context.Context, obj interface{}, state string) {
+			if obj == nil {
+				progress.log(ctx, state, nil)
+				return
+			}
+			progress.log(ctx, state, kind.replicaStatuses(obj))
+		},
+		Describe: progress.describe,
+	}
+
+	obj, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("%s", err)
+	}
+	log.Printf("[INFO] %s %s/%s reached %s", kindName, namespace, name, target)
+
+	resourceData.SetId(namespace + "/" + name)
+	return resourceData.Set("state", kind.state(obj))
+}
+
+// resourceKubeFlowJobWaitRead refreshes the state of the job. The wait is kept
+// in the state once the job is gone, since jobs are often cleaned up after
+// they finish and the wait already happened.
+func resourceKubeFlowJobWaitRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	kindName := resourceData.Get("kind").(string)
+	namespace, name, err := utils.IdParts(resourceData.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading %s %s", kindName, name)
+	obj, err := jobKinds[kindName].get(ctx, cli, namespace, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[DEBUG] %s %s no longer exists", kindName, name)
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+
+	return resourceData.Set("state", jobKinds[kindName].state(obj))
+}
+
+func resourceKubeFlowJobWaitDelete(resourceData *schema.ResourceData, meta interface{}) error {
+	resourceData.SetId("")
+	return nil
+}
@@ -0,0 +1,586 @@
+package kubeflowtraining
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
+)
+
+// kueueKind describes a Kueue object managed by one of the kubeflow_kueue_*
+// resources. Kueue has no Go types vendored, so objects go through the
+// dynamic client and the spec types below, which only hold what the
+// resources expose.
+type kueueKind struct {
+	kind       string
+	resource   string
+	namespaced bool
+	spec       *schema.Schema
+	// expandSpec and flattenSpec convert between the spec block and the spec
+	// of the object.
+	expandSpec  func(l []interface{}) (interface{}, error)
+	flattenSpec func(spec map[string]interface{}) ([]interface{}, error)
+	// counts are the workload counts of the object status exposed as computed
+	// attributes.
+	counts []kueueCount
+}
+
+type kueueCount struct {
+	name        string
+	field       string
+	description string
+}
+
+var kueueQueueCounts = []kueueCount{
+	{name: "pending_workloads", field: "pendingWorkloads", description: "Number of jobs waiting for admission by the queue."},
+	{name: "admitted_workloads", field: "admittedWorkloads", description: "Number of jobs admitted by the queue that have not finished."},
+}
+
+type kueueResourceFlavorSpec struct {
+	NodeLabels  map[string]string   `json:"nodeLabels,omitempty"`
+	NodeTaints  []corev1.Taint      `json:"nodeTaints,omitempty"`
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+type kueueClusterQueueSpec struct {
+	Cohort            string                `json:"cohort,omitempty"`
+	QueueingStrategy  string                `json:"queueingStrategy,omitempty"`
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector"`
+	ResourceGroups    []kueueResourceGroup  `json:"resourceGroups"`
+}
+
+type kueueResourceGroup struct {
+	CoveredResources []corev1.ResourceName `json:"coveredResources"`
+	Flavors          []kueueFlavorQuotas   `json:"flavors"`
+}
+
+type kueueFlavorQuotas struct {
+	Name      string               `json:"name"`
+	Resources []kueueResourceQuota `json:"resources"`
+}
+
+type kueueResourceQuota struct {
+	Name           corev1.ResourceName `json:"name"`
+	NominalQuota   resource.Quantity   `json:"nominalQuota"`
+	BorrowingLimit *resource.Quantity  `json:"borrowingLimit,omitempty"`
+}
+
+type kueueLocalQueueSpec struct {
+	ClusterQueue string `json:"clusterQueue"`
+}
+
+var kueueResourceFlavor = kueueKind{
+	kind:     "ResourceFlavor",
+	resource: "resourceflavors",
+	spec: &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Spec of the resource flavor. A flavor without spec matches every node.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"node_labels": {
+					Type:        schema.TypeMap,
+					Description: "Labels of the nodes of the flavor, which the pods of admitted jobs are given as node selector.",
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"node_taint": {
+					Type:        schema.TypeList,
+					Description: "Taints of the nodes of the flavor, which the pods of admitted jobs must tolerate.",
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"key": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"value": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"effect": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringInSlice([]string{"NoSchedule", "PreferNoSchedule", "NoExecute"}, false),
+							},
+						},
+					},
+				},
+				"toleration": kubernetes.TolerationSchema(),
+			},
+		},
+	},
+	expandSpec: func(l []interface{}) (interface{}, error) {
+		spec := &kueueResourceFlavorSpec{}
+		if len(l) == 0 || l[0] == nil {
+			return spec, nil
+		}
+		in := l[0].(map[string]interface{})
+		if v, ok := in["node_labels"].(map[string]interface{}); ok && len(v) > 0 {
+			spec.NodeLabels = utils.ExpandStringMap(v)
+		}
+		for _, t := range in["node_taint"].([]interface{}) {
+			taint := t.(map[string]interface{})
+			spec.NodeTaints = append(spec.NodeTaints, corev1.Taint{
+				Key:    taint["key"].(string),
+				Value:  taint["value"].(string),
+				Effect: corev1.TaintEffect(taint["effect"].(string)),
+			})
+		}
+		if v, ok := in["toleration"].([]interface{}); ok && len(v) > 0 {
+			tolerations, err := kubernetes.ExpandTolerations(v)
+			if err != nil {
+				return nil, err
+			}
+			spec.Tolerations = tolerations
+		}
+		return spec, nil
+	},
+	flattenSpec: func(in map[string]interface{}) ([]interface{}, error) {
+		spec := &kueueResourceFlavorSpec{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(in, spec); err != nil {
+			return nil, err
+		}
+		if len(spec.NodeLabels) == 0 && len(spec.NodeTaints) == 0 && len(spec.Tolerations) == 0 {
+			return []interface{}{}, nil
+		}
+		taints := make([]interface{}, 0, len(spec.NodeTaints))
+		for _, t := range spec.NodeTaints {
+			taints = append(taints, map[string]interface{}{
+				"key":    t.Key,
+				"value":  t.Value,
+				"effect": string(t.Effect),
+			})
+		}
+		return []interface{}{map[string]interface{}{
+			"node_labels": utils.FlattenStringMap(spec.NodeLabels),
+			"node_taint":  taints,
+			"toleration":  kubernetes.FlattenTolerations(spec.Tolerations),
+		}}, nil
+	},
+}
+
+var kueueClusterQueue = kueueKind{
+	kind:     "ClusterQueue",
+	resource: "clusterqueues",
+	spec: &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Spec of the cluster queue.",
+		Required:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cohort": {
+					Type:        schema.TypeString,
+					Description: "Cohort of the queue. Queues of a cohort borrow unused quota from each other.",
+					Optional:    true,
+				},
+				"queueing_strategy": {
+					Type:         schema.TypeString,
+					Description:  "Order in which pending jobs are admitted, BestEffortFIFO or StrictFIFO.",
+					Optional:     true,
+					Default:      "BestEffortFIFO",
+					ValidateFunc: validation.StringInSlice([]string{"BestEffortFIFO", "StrictFIFO"}, false),
+				},
+				"namespace_labels": {
+					Type:        schema.TypeMap,
+					Description: "Labels of the namespaces whose local queues may submit to the queue. Every namespace may when empty.",
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"resource_group": {
+					Type:        schema.TypeList,
+					Description: "Quotas of a group of resources, per flavor. Kueue assigns a job the first flavor of a group with enough quota left.",
+					Required:    true,
+					MinItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"covered_resources": {
+								Type:        schema.TypeList,
+								Description: "Resources of the group, e.g. cpu, memory and nvidia.com/gpu.",
+								Required:    true,
+								MinItems:    1,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+							"flavor": {
+								Type:        schema.TypeList,
+								Description: "Quotas of a resource flavor, in order of preference.",
+								Required:    true,
+								MinItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"name": {
+											Type:        schema.TypeString,
+											Description: "Name of the resource flavor.",
+											Required:    true,
+										},
+										"resource": {
+											Type:        schema.TypeList,
+											Description: "Quota of each covered resource.",
+											Required:    true,
+											MinItems:    1,
+											Elem: &schema.Resource{
+												Schema: map[string]*schema.Schema{
+													"name": {
+														Type:        schema.TypeString,
+														Description: "Name of the resource.",
+														Required:    true,
+													},
+													"nominal_quota":   kubernetes.ResourceQuantitySchema("Quantity of the resource available to the queue.", true),
+													"borrowing_limit": kubernetes.ResourceQuantitySchema("Quantity of the resource the queue may borrow from its cohort. Unlimited when unset.", false),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	expandSpec: func(l []interface{}) (interface{}, error) {
+		in := l[0].(map[string]interface{})
+		spec := &kueueClusterQueueSpec{
+			Cohort:            in["cohort"].(string),
+			QueueingStrategy:  in["queueing_strategy"].(string),
+			NamespaceSelector: &metav1.LabelSelector{},
+		}
+		if v, ok := in["namespace_labels"].(map[string]interface{}); ok && len(v) > 0 {
+			spec.NamespaceSelector.MatchLabels = utils.ExpandStringMap(v)
+		}
+		for _, g := range in["resource_group"].([]interface{}) {
+			group := g.(map[string]interface{})
+			rg := kueueResourceGroup{}
+			for _, r := range group["covered_resources"].([]interface{}) {
+				rg.CoveredResources = append(rg.CoveredResources, corev1.ResourceName(r.(string)))
+			}
+			for _, f := range group["flavor"].([]interface{}) {
+				flavor := f.(map[string]interface{})
+				fq := kueueFlavorQuotas{Name: flavor["name"].(string)}
+				for _, r := range flavor["resource"].([]interface{}) {
+					quota := r.(map[string]interface{})
+					rq := kueueResourceQuota{Name: corev1.ResourceName(quota["name"].(string))}
+					q, err := resource.ParseQuantity(quota["nominal_quota"].(string))
+					if err != nil {
+						return nil, fmt.Errorf("invalid nominal_quota of %s: %s", rq.Name, err)
+					}
+					rq.NominalQuota = q
+					if v := quota["borrowing_limit"].(string); v != "" {
+						q, err := resource.ParseQuantity(v)
+						if err != nil {
+							return nil, fmt.Errorf("invalid borrowing_limit of %s: %s", rq.Name, err)
+						}
+						rq.BorrowingLimit = &q
+					}
+					fq.Resources = append(fq.Resources, rq)
+				}
+				rg.Flavors = append(rg.Flavors, fq)
+			}
+			spec.ResourceGroups = append(spec.ResourceGroups, rg)
+		}
+		return spec, nil
+	},
+	flattenSpec: func(in map[string]interface{}) ([]interface{}, error) {
+		spec := &kueueClusterQueueSpec{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(in, spec); err != nil {
+			return nil, err
+		}
+		att := map[string]interface{}{
+			"cohort":            spec.Cohort,
+			"queueing_strategy": spec.QueueingStrategy,
+		}
+		if spec.NamespaceSelector != nil {
+			att["namespace_labels"] = utils.FlattenStringMap(spec.NamespaceSelector.MatchLabels)
+		}
+		groups := make([]interface{}, 0, len(spec.ResourceGroups))
+		for _, rg := range spec.ResourceGroups {
+			covered := make([]interface{}, 0, len(rg.CoveredResources))
+			for _, r := range rg.CoveredResources {
+				covered = append(covered, string(r))
+			}
+			flavors := make([]interface{}, 0, len(rg.Flavors))
+			for _, fq := range rg.Flavors {
+				quotas := make([]interface{}, 0, len(fq.Resources))
+				for _, rq := range fq.Resources {
+					quota := map[string]interface{}{
+						"name":          string(rq.Name),
+						"nominal_quota": rq.NominalQuota.String(),
+					}
+					if rq.BorrowingLimit != nil {
+						quota["borrowing_limit"] = rq.BorrowingLimit.String()
+					}
+					quotas = append(quotas, quota)
+				}
+				flavors = append(flavors, map[string]interface{}{
+					"name":     fq.Name,
+					"resource": quotas,
+				})
+			}
+			groups = append(groups, map[string]interface{}{
+				"covered_resources": covered,
+				"flavor":            flavors,
+			})
+		}
+		att["resource_group"] = groups
+		return []interface{}{att}, nil
+	},
+	counts: kueueQueueCounts,
+}
+
+var kueueLocalQueue = kueueKind{
+	kind:       "LocalQueue",
+	resource:   "localqueues",
+	namespaced: true,
+	spec: &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Spec of the local queue.",
+		Required:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cluster_queue": {
+					Type:        schema.TypeString,
+					Description: "Name of the cluster queue the jobs of the local queue are admitted by.",
+					Required:    true,
+					ForceNew:    true,
+				},
+			},
+		},
+	},
+	expandSpec: func(l []interface{}) (interface{}, error) {
+		in := l[0].(map[string]interface{})
+		return &kueueLocalQueueSpec{ClusterQueue: in["cluster_queue"].(string)}, nil
+	},
+	flattenSpec: func(in map[string]interface{}) ([]interface{}, error) {
+		spec := &kueueLocalQueueSpec{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(in, spec); err != nil {
+			return nil, err
+		}
+		return []interface{}{map[string]interface{}{
+			"cluster_queue": spec.ClusterQueue,
+		}}, nil
+	},
+	counts: kueueQueueCounts,
+}
+
+func resourceKubeFlowKueueResourceFlavor() *schema.Resource {
+	return resourceKubeFlowKueueObject(kueueResourceFlavor)
+}
+
+func resourceKubeFlowKueueClusterQueue() *schema.Resource {
+	return resourceKubeFlowKueueObject(kueueClusterQueue)
+}
+
+func resourceKubeFlowKueueLocalQueue() *schema.Resource {
+	return resourceKubeFlowKueueObject(kueueLocalQueue)
+}
+
+func resourceKubeFlowKueueObject(k kueueKind) *schema.Resource {
+	fields := map[string]*schema.Schema{
+		"metadata": kubernetes.MetadataSchema(k.kind, false),
+		"spec":     k.spec,
+	}
+	if k.namespaced {
+		fields["metadata"] = kubernetes.NamespacedMetadataSchema(k.kind, false)
+	}
+	for _, count := range k.counts {
+		fields[count.name] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Description: count.description,
+			Computed:    true,
+		}
+	}
+
+	return &schema.Resource{
+		Create: func(resourceData *schema.ResourceData, meta interface{}) error {
+			return resourceKubeFlowKueueObjectCreate(k, resourceData, meta)
+		},
+		Read: func(resourceData *schema.ResourceData, meta interface{}) error {
+			return resourceKubeFlowKueueObjectRead(k, resourceData, meta)
+		},
+		Update: func(resourceData *schema.ResourceData, meta interface{}) error {
+			return resourceKubeFlowKueueObjectUpdate(k, resourceData, meta)
+		},
+		Delete: func(resourceData *schema.ResourceData, meta interface{}) error {
+			return resourceKubeFlowKueueObjectDelete(k, resourceData, meta)
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: fields,
+	}
+}
+
+func resourceKubeFlowKueueObjectCreate(k kueueKind, resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	metadata := kubernetes.ExpandMetadata(resourceData.Get("metadata").([]interface{}))
+	spec, err := expandKueueSpec(k, resourceData)
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetKind(k.kind)
+	obj.SetName(metadata.Name)
+	obj.SetNamespace(metadata.Namespace)
+	obj.SetLabels(metadata.Labels)
+	obj.SetAnnotations(metadata.Annotations)
+
+	log.Printf("[INFO] Creating new Kueue %s: %s", k.kind, obj.GetName())
+	out, err := cli.CreateKueueObject(ctx, k.resource, obj)
+	if err != nil {
+		return err
+	}
+	if k.namespaced {
+		resourceData.SetId(out.GetNamespace() + "/" + out.GetName())
+	} else {
+		resourceData.SetId(out.GetName())
+	}
+
+	return setKueueObject(k, resourceData, out)
+}
+
+func resourceKubeFlowKueueObjectRead(k kueueKind, resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	namespace, name, err := kueueIdParts(k, resourceData.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading Kueue %s %s", k.kind, resourceData.Id())
+	obj, err := cli.GetKueueObject(ctx, k.resource, namespace, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[DEBUG] Kueue %s %s no longer exists", k.kind, resourceData.Id())
+			resourceData.SetId("")
+			return nil
+		}
+		return err
+	}
+	return setKueueObject(k, resourceData, obj)
+}
+
+func resourceKubeFlowKueueObjectUpdate(k kueueKind, resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutUpdate)
+	defer cancel()
+
+	namespace, name, err := kueueIdParts(k, resourceData.Id())
+	if err != nil {
+		return err
+	}
+
+	ops := kubernetes.AppendPatchOps("metadata.0.", "/metadata/", resourceData, make([]patch.PatchOperation, 0))
+	if resourceData.HasChange("spec") {
+		spec, err := expandKueueSpec(k, resourceData)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, &patch.AddOperation{Path: "/spec", Value: spec})
+	}
+	if len(ops) == 0 {
+		return resourceKubeFlowKueueObjectRead(k, resourceData, meta)
+	}
+
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating Kueue %s %s: %s", k.kind, resourceData.Id(), data)
+	out, err := cli.UpdateKueueObject(ctx, k.resource, namespace, name, data)
+	if err != nil {
+		return err
+	}
+	return setKueueObject(k, resourceData, out)
+}
+
+func resourceKubeFlowKueueObjectDelete(k kueueKind, resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	namespace, name, err := kueueIdParts(k, resourceData.Id())
+	if err != nil {
+		return err
+	}
+
+	// Kueue keeps queues and flavors in use around until their workloads are
+	// done, through finalizers; there is nothing to wait for here.
+	log.Printf("[INFO] Deleting Kueue %s %s", k.kind, resourceData.Id())
+	if err := cli.DeleteKueueObject(ctx, k.resource, namespace, name); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	resourceData.SetId("")
+	return nil
+}
+
+func kueueIdParts(k kueueKind, id string) (string, string, error) {
+	if k.namespaced {
+		return utils.IdParts(id)
+	}
+	return "", id, nil
+}
+
+// expandKueueSpec returns the spec block as the spec of an unstructured
+// object.
+func expandKueueSpec(k kueueKind, resourceData *schema.ResourceData) (map[string]interface{}, error) {
+	spec, err := k.expandSpec(resourceData.Get("spec").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+}
+
+func setKueueObject(k kueueKind, resourceData *schema.ResourceData, obj *unstructured.Unstructured) error {
+	metadata := metav1.ObjectMeta{
+		Name:            obj.GetName(),
+		Namespace:       obj.GetNamespace(),
+		Labels:          obj.GetLabels(),
+		Annotations:     obj.GetAnnotations(),
+		ResourceVersion: obj.GetResourceVersion(),
+		SelfLink:        obj.GetSelfLink(),
+		UID:             obj.GetUID(),
+		Generation:      obj.GetGeneration(),
+	}
+	if err := resourceData.Set("metadata", kubernetes.FlattenMetadata(metadata)); err != nil {
+		return err
+	}
+
+	in, _, _ := unstructured.NestedMap(obj.Object, "spec")
+	spec, err := k.flattenSpec(in)
+	if err != nil {
+		return fmt.Errorf("unable to read the spec of %s %s: %s", k.kind, obj.GetName(), err)
+	}
+	if err := resourceData.Set("spec", spec); err != nil {
+		return err
+	}
+
+	for _, count := range k.counts {
+		n, _, _ := unstructured.NestedInt64(obj.Object, "status", count.field)
+		if err := resourceData.Set(count.name, int(n)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
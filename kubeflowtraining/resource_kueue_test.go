@@ -0,0 +1,90 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client/mock"
+)
+
+func TestKueueClusterQueueSpec(t *testing.T) {
+	spec := []interface{}{map[string]interface{}{
+		"cohort":            "research",
+		"queueing_strategy": "StrictFIFO",
+		"resource_group": []interface{}{map[string]interface{}{
+			"covered_resources": []interface{}{"cpu", "nvidia.com/gpu"},
+			"flavor": []interface{}{map[string]interface{}{
+				"name": "a100",
+				"resource": []interface{}{
+					map[string]interface{}{"name": "cpu", "nominal_quota": "64"},
+					map[string]interface{}{"name": "nvidia.com/gpu", "nominal_quota": "8", "borrowing_limit": "4"},
+				},
+			}},
+		}},
+	}}
+	resourceData := schema.TestResourceDataRaw(t, resourceKubeFlowKueueClusterQueue().Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "team"}},
+		"spec":     spec,
+	})
+
+	obj, err := expandKueueSpec(kueueClusterQueue, resourceData)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if selector, ok := obj["namespaceSelector"].(map[string]interface{}); !ok || len(selector) != 0 {
+		t.Fatalf("expected an empty namespace selector matching every namespace, got %#v", obj["namespaceSelector"])
+	}
+
+	flattened, err := kueueClusterQueue.flattenSpec(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := resourceData.Set("spec", flattened); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Unset attributes read back as zero values.
+	expected := spec[0].(map[string]interface{})
+	expected["namespace_labels"] = map[string]interface{}{}
+	quotas := expected["resource_group"].([]interface{})[0].(map[string]interface{})["flavor"].([]interface{})[0].(map[string]interface{})["resource"].([]interface{})
+	quotas[0].(map[string]interface{})["borrowing_limit"] = ""
+	if got := resourceData.Get("spec"); !reflect.DeepEqual(got, spec) {
+		t.Fatalf("expected spec %#v, got %#v", spec, got)
+	}
+}
+
+func TestJobProgressAdmission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workload := unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{
+				"type":    "QuotaReserved",
+				"status":  "False",
+				"reason":  "Pending",
+				"message": "couldn't assign flavors to pod set master: insufficient quota for nvidia.com/gpu",
+			}},
+		},
+	}}
+	workload.SetName("pytorchjob-test-1a2b3")
+	workload.SetOwnerReferences([]metav1.OwnerReference{{Kind: "PyTorchJob", Name: "test"}})
+
+	cli := mock.NewMockClient(ctrl)
+	cli.EXPECT().ListKueueObjects(gomock.Any(), "workloads", "default").Return([]unstructured.Unstructured{workload}, nil)
+
+	progress := newJobProgress(cli, "PyTorchJob", "default", "test")
+	description := progress.describe(context.Background(), testPyTorchJob("1"), "Suspended")
+	if !strings.HasPrefix(description, "Pending admission") || !strings.Contains(description, "insufficient quota for nvidia.com/gpu") {
+		t.Fatalf("unexpected description %q", description)
+	}
+	if description := progress.describe(context.Background(), testPyTorchJob("1"), "Running"); description != "" {
+		t.Fatalf("expected no description of a running job, got %q", description)
+	}
+}
@@ -1,20 +1,21 @@
 package kubeflowtraining
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
-	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	mpiv2beta1 "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/mpi_job"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 func resourceKubeFlowMPIJob() *schema.Resource {
@@ -27,6 +28,7 @@ func resourceKubeFlowMPIJob() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeDiffSchedulingPolicy,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
@@ -37,104 +39,88 @@ func resourceKubeFlowMPIJob() *schema.Resource {
 
 func resourceKubeFlowMPIJobCreate(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
 
 	mpij, err := mpi_job.FromResourceData(resourceData)
 	if err != nil {
 		return err
 	}
-
-	log.Printf("[INFO] Creating new data volume: %#v", mpij)
-	if err := cli.CreateMPIJob(mpij); err != nil {
+	if err := checkJobQuota(ctx, cli, resourceData, "MPIJob", mpij.Namespace, mpij.Name, mpij); err != nil {
 		return err
 	}
-	log.Printf("[INFO] Submitted new data volume: %#v", mpij)
+
+	log.Printf("[INFO] Creating new data volume: %s", utils.Redacted(mpij))
+	if err := cli.CreateMPIJob(ctx, mpij); err != nil {
+		existing, err := adoptExistingJob(ctx, cli, resourceData, "MPIJob", mpij.Namespace, mpij.Name, mpij, err)
+		if err != nil {
+			return err
+		}
+		mpij = existing.(*mpiv2beta1.MPIJob)
+	}
+	log.Printf("[INFO] Submitted new data volume: %s", utils.Redacted(mpij))
 	if err := mpi_job.ToResourceData(*mpij, resourceData); err != nil {
 		return err
 	}
 	resourceData.SetId(utils.BuildId(mpij.ObjectMeta))
+	if !resourceData.Get("wait_for_completion").(bool) {
+		log.Printf("[INFO] Not waiting for MPIJob %s to complete, wait_for_completion is false", mpij.Name)
+		return nil
+	}
 
 	// Wait for data volume instance's status phase to be succeeded:
 	name := mpij.ObjectMeta.Name
 	namespace := mpij.ObjectMeta.Namespace
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Creating"},
+	progress := newJobProgress(cli, "MPIJob", namespace, name)
+	stateConf := &jobStateConf{
+		Pending: jobPendingStates,
 		Target:  []string{"Succeeded"},
 		Timeout: resourceData.Timeout(schema.TimeoutCreate),
 		Refresh: func() (interface{}, string, error) {
-			var err error
-			mpij, err = cli.GetMPIJob(namespace, name)
+			mpij, err := cli.GetMPIJob(ctx, namespace, name)
 			if err != nil {
 				if errors.IsNotFound(err) {
-					log.Printf("[DEBUG] data volume %s is not created yet", name)
-					return mpij, "Creating", nil
+					log.Printf("[DEBUG] MPIJob %s is not created yet", name)
+					return nil, "Creating", nil
 				}
-				return mpij, "", err
+				return nil, "", err
 			}
-			for _, c := range mpij.Status.Conditions {
-				if c.Type == commonv1.JobSucceeded && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] MPIJob %s is succeeded", name)
-					return mpij, "Succeeded", nil
-				}
-
-				if c.Type == commonv1.JobFailed && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] MPIJob %s is failed", name)
-					return mpij, "Failed", nil
-				}
-
-				if c.Type == commonv1.JobRunning && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] MPIJob %s is running", name)
-					return mpij, "Running", nil
-				}
-
-				if c.Type == commonv1.JobRunning && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] MPIJob %s is pending", name)
-					return mpij, "Pending", nil
-				}
-
-				if c.Type == commonv1.JobCreated && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] MPIJob %s is created", name)
-					return mpij, "Created", nil
-				}
-
-				if c.Type == commonv1.JobCreated && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] MPIJob %s is creating", name)
-					return mpij, "Creating", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] MPIJob %s is restarting", name)
-					return mpij, "Restarting", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] MPIJob %s is restarting", name)
-					return mpij, "Restarting", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionUnknown {
-					log.Printf("[DEBUG] MPIJob %s is restarting", name)
-					return mpij, "Restarting", nil
-				}
-			}
-
-			if mpij.Status.StartTime == nil {
-				log.Printf("[DEBUG] MPIJob %s is not started yet", name)
-				return mpij, "Creating", nil
+			return mpij, mpijState(mpij), nil
+		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return cli.WatchMPIJob(ctx, namespace, name, resourceVersion)
+		},
+		State: func(obj interface{}) (string, error) {
+			return mpijState(obj.(*mpiv2beta1.MPIJob)), nil
+		},
+		Progress: func(ctx context.Context, obj interface{}, state string) {
+			var replicaStatuses map[commonv1.ReplicaType]*commonv1.ReplicaStatus
+			if mpij, ok := obj.(*mpiv2beta1.MPIJob); ok {
+				replicaStatuses = mpiJobReplicaStatuses(mpij.Status.ReplicaStatuses)
 			}
-			log.Printf("[DEBUG] data volume %s is being created", name)
-			return mpij, "Creating", nil
+			progress.log(ctx, state, replicaStatuses)
 		},
+		Describe: progress.describe,
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	obj, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
 		return fmt.Errorf("%s", err)
 	}
-	return mpi_job.ToResourceData(*mpij, resourceData)
+	mpij = obj.(*mpiv2beta1.MPIJob)
+
+	if err := mpi_job.ToResourceData(*mpij, resourceData); err != nil {
+		return err
+	}
+
+	return setJobOutputs(ctx, cli, resourceData, "MPIJob", namespace, name)
 }
 
 func resourceKubeFlowMPIJobRead(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -143,18 +129,20 @@ func resourceKubeFlowMPIJobRead(resourceData *schema.ResourceData, meta interfac
 
 	log.Printf("[INFO] Reading data volume %s", name)
 
-	mpij, err := cli.GetMPIJob(namespace, name)
+	mpij, err := cli.GetMPIJob(ctx, namespace, name)
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
-	log.Printf("[INFO] Received data volume: %#v", mpij)
+	log.Printf("[INFO] Received data volume: %s", utils.Redacted(mpij))
 
 	return mpi_job.ToResourceData(*mpij, resourceData)
 }
 
 func resourceKubeFlowMPIJobUpdate(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutUpdate)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -162,57 +150,86 @@ func resourceKubeFlowMPIJobUpdate(resourceData *schema.ResourceData, meta interf
 	}
 
 	ops := mpi_job.AppendPatchOps("", "", resourceData, []patch.PatchOperation{})
-	data, err := ops.MarshalJSON()
-	if err != nil {
-		return fmt.Errorf("[DEBUG] Failed to marshal update operations: %s", err)
-	}
-
 	log.Printf("[INFO] Updating data volume: %s", ops)
-	out := &kubeflowv1.MPIJob{}
-	if err := cli.UpdateMPIJob(namespace, name, out, data); err != nil {
+	out := &mpiv2beta1.MPIJob{}
+	err = updateJob(ctx, cli, resourceData, "MPIJob", namespace, name, ops, func(data []byte) error {
+		return cli.UpdateMPIJob(ctx, namespace, name, out, data)
+	})
+	if err != nil {
 		return err
 	}
 
-	log.Printf("[INFO] Submitted updated data volume: %#v", out)
+	log.Printf("[INFO] Submitted updated data volume: %s", utils.Redacted(out))
 
 	return resourceKubeFlowMPIJobRead(resourceData, meta)
 }
 
 func resourceKubeFlowMPIJobDelete(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
 		return err
 	}
 
+	err = checkJobDestroy(resourceData, "MPIJob", name, func() (string, error) {
+		mpij, err := cli.GetMPIJob(ctx, namespace, name)
+		if err != nil {
+			return "", err
+		}
+		return mpijState(mpij), nil
+	})
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Deleting data volume: %#v", name)
-	if err := cli.DeleteMPIJob(namespace, name); err != nil {
+	deleteOptions, waitForPods, err := kubernetes.ExpandDeleteOptions(resourceData.Get("delete_options").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err := cli.DeleteMPIJob(ctx, namespace, name, deleteOptions); err != nil {
 		return err
 	}
 
 	// Wait for data volume instance to be removed:
-	stateConf := &resource.StateChangeConf{
+	stateConf := &jobStateConf{
 		Pending: []string{"Deleting"},
 		Timeout: resourceData.Timeout(schema.TimeoutDelete),
 		Refresh: func() (interface{}, string, error) {
-			mpij, err := cli.GetMPIJob(namespace, name)
+			mpij, err := cli.GetMPIJob(ctx, namespace, name)
 			if err != nil {
 				if errors.IsNotFound(err) {
 					return nil, "", nil
 				}
-				return mpij, "", err
+				return nil, "", err
 			}
 
-			log.Printf("[DEBUG] data volume %s is being deleted", mpij.GetName())
+			log.Printf("[DEBUG] MPIJob %s is being deleted", mpij.GetName())
 			return mpij, "Deleting", nil
 		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return cli.WatchMPIJob(ctx, namespace, name, resourceVersion)
+		},
+		State: func(obj interface{}) (string, error) {
+			log.Printf("[DEBUG] MPIJob %s is being deleted", name)
+			return "Deleting", nil
+		},
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
 		return fmt.Errorf("%s", err)
 	}
 
+	if waitForPods {
+		log.Printf("[INFO] Waiting for pods of MPIJob %s to be deleted", name)
+		if err := waitForJobPodsDeleted(ctx, cli, namespace, name, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("%s", err)
+		}
+	}
+
 	log.Printf("[INFO] data volume %s deleted", name)
 
 	resourceData.SetId("")
@@ -221,6 +238,8 @@ func resourceKubeFlowMPIJobDelete(resourceData *schema.ResourceData, meta interf
 
 func resourceKubeFlowMPIJobExists(resourceData *schema.ResourceData, meta interface{}) (bool, error) {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -228,7 +247,7 @@ func resourceKubeFlowMPIJobExists(resourceData *schema.ResourceData, meta interf
 	}
 
 	log.Printf("[INFO] Checking data volume %s", name)
-	if _, err := cli.GetMPIJob(namespace, name); err != nil {
+	if _, err := cli.GetMPIJob(ctx, namespace, name); err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
 			return false, nil
 		}
@@ -237,3 +256,46 @@ func resourceKubeFlowMPIJobExists(resourceData *schema.ResourceData, meta interf
 	}
 	return true, nil
 }
+
+// mpijState reports the state of a MPIJob from its status conditions.
+func mpijState(mpij *mpiv2beta1.MPIJob) string {
+	state := jobConditionsState(mpiJobConditions(mpij.Status.Conditions))
+	log.Printf("[DEBUG] MPIJob %s is in state %s", mpij.Name, state)
+	return state
+}
+
+// mpiJobConditions converts the mpi-operator job conditions to their
+// kubeflow/common equivalent, which share the same types and semantics.
+func mpiJobConditions(in []mpiv2beta1.JobCondition) []commonv1.JobCondition {
+	out := make([]commonv1.JobCondition, len(in))
+	for i, c := range in {
+		out[i] = commonv1.JobCondition{
+			Type:               commonv1.JobConditionType(c.Type),
+			Status:             c.Status,
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastUpdateTime:     c.LastUpdateTime,
+			LastTransitionTime: c.LastTransitionTime,
+		}
+	}
+	return out
+}
+
+// mpiJobReplicaStatuses converts the mpi-operator replica statuses to their
+// kubeflow/common equivalent.
+func mpiJobReplicaStatuses(in map[mpiv2beta1.MPIReplicaType]*mpiv2beta1.ReplicaStatus) map[commonv1.ReplicaType]*commonv1.ReplicaStatus {
+	out := make(map[commonv1.ReplicaType]*commonv1.ReplicaStatus, len(in))
+	for k, v := range in {
+		if v == nil {
+			continue
+		}
+		out[commonv1.ReplicaType(k)] = &commonv1.ReplicaStatus{
+			Active:        v.Active,
+			Succeeded:     v.Succeeded,
+			Failed:        v.Failed,
+			LabelSelector: v.LabelSelector,
+			Selector:      v.Selector,
+		}
+	}
+	return out
+}
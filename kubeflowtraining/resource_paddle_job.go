@@ -1,20 +1,21 @@
 package kubeflowtraining
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/paddle_job"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 func resourceKubeFlowPaddleJob() *schema.Resource {
@@ -37,106 +38,85 @@ func resourceKubeFlowPaddleJob() *schema.Resource {
 
 func resourceKubeFlowPaddleJobCreate(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
 
 	pj, err := paddle_job.FromResourceData(resourceData)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("[INFO] Creating new PaddleJob: %#v", pj)
-	if err := cli.CreatePaddleJob(pj); err != nil {
-		return err
+	log.Printf("[INFO] Creating new PaddleJob: %s", utils.Redacted(pj))
+	if err := cli.CreatePaddleJob(ctx, pj); err != nil {
+		existing, err := adoptExistingJob(ctx, cli, resourceData, "PaddleJob", pj.Namespace, pj.Name, pj, err)
+		if err != nil {
+			return err
+		}
+		pj = existing.(*kubeflowv1.PaddleJob)
 	}
-	log.Printf("[INFO] Submitted new PaddleJob: %#v", pj)
+	log.Printf("[INFO] Submitted new PaddleJob: %s", utils.Redacted(pj))
 	if err := paddle_job.ToResourceData(*pj, resourceData); err != nil {
 		return err
 	}
 	resourceData.SetId(utils.BuildId(pj.ObjectMeta))
+	if !resourceData.Get("wait_for_completion").(bool) {
+		log.Printf("[INFO] Not waiting for PaddleJob %s to complete, wait_for_completion is false", pj.Name)
+		return nil
+	}
 
 	// Wait for PaddleJob instance's status phase to be succeeded:
 	name := pj.ObjectMeta.Name
 	namespace := pj.ObjectMeta.Namespace
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Creating"},
+	progress := newJobProgress(cli, "PaddleJob", namespace, name)
+	stateConf := &jobStateConf{
+		Pending: jobPendingStates,
 		Target:  []string{"Succeeded"},
 		Timeout: resourceData.Timeout(schema.TimeoutCreate),
 		Refresh: func() (interface{}, string, error) {
-			var err error
-			pj, err = cli.GetPaddleJob(namespace, name)
+			pj, err := cli.GetPaddleJob(ctx, namespace, name)
 			if err != nil {
 				if errors.IsNotFound(err) {
 					log.Printf("[DEBUG] PaddleJob %s is not created yet", name)
-					return pj, "Creating", nil
+					return nil, "Creating", nil
 				}
-				return pj, "", err
+				return nil, "", err
 			}
-
-			for _, c := range pj.Status.Conditions {
-				if c.Type == commonv1.JobSucceeded && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] PadddleJob %s is succeeded", name)
-					return pj, "Succeeded", nil
-				}
-
-				if c.Type == commonv1.JobFailed && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] PadddleJob %s is failed", name)
-					return pj, "Failed", nil
-				}
-
-				if c.Type == commonv1.JobRunning && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] PadddleJob %s is running", name)
-					return pj, "Running", nil
-				}
-
-				if c.Type == commonv1.JobRunning && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] PadddleJob %s is pending", name)
-					return pj, "Pending", nil
-				}
-
-				if c.Type == commonv1.JobCreated && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] PadddleJob %s is created", name)
-					return pj, "Created", nil
-				}
-
-				if c.Type == commonv1.JobCreated && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] PadddleJob %s is creating", name)
-					return pj, "Creating", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] PadddleJob %s is restarting", name)
-					return pj, "Restarting", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] PadddleJob %s is restarting", name)
-					return pj, "Restarting", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionUnknown {
-					log.Printf("[DEBUG] PadddleJob %s is restarting", name)
-					return pj, "Restarting", nil
-				}
-			}
-
-			if pj.Status.StartTime == nil {
-				log.Printf("[DEBUG] PadddleJob %s is not started yet", name)
-				return pj, "Creating", nil
+			return pj, pjState(pj), nil
+		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return cli.WatchPaddleJob(ctx, namespace, name, resourceVersion)
+		},
+		State: func(obj interface{}) (string, error) {
+			return pjState(obj.(*kubeflowv1.PaddleJob)), nil
+		},
+		Progress: func(ctx context.Context, obj interface{}, state string) {
+			var replicaStatuses map[commonv1.ReplicaType]*commonv1.ReplicaStatus
+			if pj, ok := obj.(*kubeflowv1.PaddleJob); ok {
+				replicaStatuses = pj.Status.ReplicaStatuses
 			}
-
-			log.Printf("[DEBUG] PaddleJob %s is being created", name)
-			return pj, "Creating", nil
+			progress.log(ctx, state, replicaStatuses)
 		},
+		Describe: progress.describe,
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	obj, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
 		return fmt.Errorf("%s", err)
 	}
-	return paddle_job.ToResourceData(*pj, resourceData)
+	pj = obj.(*kubeflowv1.PaddleJob)
+
+	if err := paddle_job.ToResourceData(*pj, resourceData); err != nil {
+		return err
+	}
+
+	return setJobOutputs(ctx, cli, resourceData, "PaddleJob", namespace, name)
 }
 
 func resourceKubeFlowPaddleJobRead(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -145,18 +125,20 @@ func resourceKubeFlowPaddleJobRead(resourceData *schema.ResourceData, meta inter
 
 	log.Printf("[INFO] Reading PaddleJob %s", name)
 
-	pj, err := cli.GetPaddleJob(namespace, name)
+	pj, err := cli.GetPaddleJob(ctx, namespace, name)
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
-	log.Printf("[INFO] Received PaddleJob: %#v", pj)
+	log.Printf("[INFO] Received PaddleJob: %s", utils.Redacted(pj))
 
 	return paddle_job.ToResourceData(*pj, resourceData)
 }
 
 func resourceKubeFlowPaddleJobUpdate(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutUpdate)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -164,57 +146,86 @@ func resourceKubeFlowPaddleJobUpdate(resourceData *schema.ResourceData, meta int
 	}
 
 	ops := paddle_job.AppendPatchOps("", "", resourceData, []patch.PatchOperation{})
-	data, err := ops.MarshalJSON()
-	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
-	}
-
 	log.Printf("[INFO] Updating PaddleJob: %s", ops)
 	out := &kubeflowv1.PaddleJob{}
-	if err := cli.UpdatePaddleJob(namespace, name, out, data); err != nil {
+	err = updateJob(ctx, cli, resourceData, "PaddleJob", namespace, name, ops, func(data []byte) error {
+		return cli.UpdatePaddleJob(ctx, namespace, name, out, data)
+	})
+	if err != nil {
 		return err
 	}
 
-	log.Printf("[INFO] Submitted updated PaddleJob: %#v", out)
+	log.Printf("[INFO] Submitted updated PaddleJob: %s", utils.Redacted(out))
 
 	return resourceKubeFlowPaddleJobRead(resourceData, meta)
 }
 
 func resourceKubeFlowPaddleJobDelete(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
 		return err
 	}
 
+	err = checkJobDestroy(resourceData, "PaddleJob", name, func() (string, error) {
+		pj, err := cli.GetPaddleJob(ctx, namespace, name)
+		if err != nil {
+			return "", err
+		}
+		return pjState(pj), nil
+	})
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Deleting PaddleJob: %#v", name)
-	if err := cli.DeletePaddleJob(namespace, name); err != nil {
+	deleteOptions, waitForPods, err := kubernetes.ExpandDeleteOptions(resourceData.Get("delete_options").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err := cli.DeletePaddleJob(ctx, namespace, name, deleteOptions); err != nil {
 		return err
 	}
 
 	// Wait for PaddleJob instance to be removed:
-	stateConf := &resource.StateChangeConf{
+	stateConf := &jobStateConf{
 		Pending: []string{"Deleting"},
 		Timeout: resourceData.Timeout(schema.TimeoutDelete),
 		Refresh: func() (interface{}, string, error) {
-			pj, err := cli.GetPaddleJob(namespace, name)
+			pj, err := cli.GetPaddleJob(ctx, namespace, name)
 			if err != nil {
 				if errors.IsNotFound(err) {
 					return nil, "", nil
 				}
-				return pj, "", err
+				return nil, "", err
 			}
 
 			log.Printf("[DEBUG] PaddleJob %s is being deleted", pj.GetName())
 			return pj, "Deleting", nil
 		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return cli.WatchPaddleJob(ctx, namespace, name, resourceVersion)
+		},
+		State: func(obj interface{}) (string, error) {
+			log.Printf("[DEBUG] PaddleJob %s is being deleted", name)
+			return "Deleting", nil
+		},
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
 		return fmt.Errorf("%s", err)
 	}
 
+	if waitForPods {
+		log.Printf("[INFO] Waiting for pods of PaddleJob %s to be deleted", name)
+		if err := waitForJobPodsDeleted(ctx, cli, namespace, name, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("%s", err)
+		}
+	}
+
 	log.Printf("[INFO] PaddleJob %s deleted", name)
 
 	resourceData.SetId("")
@@ -223,6 +234,8 @@ func resourceKubeFlowPaddleJobDelete(resourceData *schema.ResourceData, meta int
 
 func resourceKubeFlowPaddleJobExists(resourceData *schema.ResourceData, meta interface{}) (bool, error) {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -230,7 +243,7 @@ func resourceKubeFlowPaddleJobExists(resourceData *schema.ResourceData, meta int
 	}
 
 	log.Printf("[INFO] Checking PaddleJob %s", name)
-	if _, err := cli.GetPaddleJob(namespace, name); err != nil {
+	if _, err := cli.GetPaddleJob(ctx, namespace, name); err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
 			return false, nil
 		}
@@ -239,3 +252,10 @@ func resourceKubeFlowPaddleJobExists(resourceData *schema.ResourceData, meta int
 	}
 	return true, nil
 }
+
+// pjState reports the state of a PaddleJob from its status conditions.
+func pjState(pj *kubeflowv1.PaddleJob) string {
+	state := jobConditionsState(pj.Status.Conditions)
+	log.Printf("[DEBUG] PaddleJob %s is in state %s", pj.Name, state)
+	return state
+}
@@ -0,0 +1,252 @@
+package kubeflowtraining
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+func resourceKubeFlowPipelinesPipeline() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubeFlowPipelinesPipelineCreate,
+		Read:   resourceKubeFlowPipelinesPipelineRead,
+		Update: resourceKubeFlowPipelinesPipelineUpdate,
+		Delete: resourceKubeFlowPipelinesPipelineDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the pipeline.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Type:          schema.TypeString,
+				Description:   "Description of the pipeline. The API ignores descriptions of pipelines created from a URL.",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"url"},
+			},
+			"url": {
+				Type:          schema.TypeString,
+				Description:   "URL of the pipeline definition.",
+				Optional:      true,
+				ExactlyOneOf:  []string{"url", "file_base64"},
+				ValidateFunc:  validation.IsURLWithHTTPorHTTPS,
+				ConflictsWith: []string{"description"},
+			},
+			"file_base64": {
+				Type:         schema.TypeString,
+				Description:  "The base64 encoded pipeline definition.",
+				Optional:     true,
+				ExactlyOneOf: []string{"url", "file_base64"},
+				RequiredWith: []string{"file_format"},
+				ValidateFunc: validation.StringIsBase64,
+			},
+			"file_format": {
+				Type:         schema.TypeString,
+				Description:  "Format of the pipeline definition in file_base64. One of zip, tar.gz or yaml.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"zip", "tar.gz", "yaml"}, false),
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Description: "Name of the pipeline version. Changing the definition or the version uploads a new version of the pipeline instead of replacing it, so that runs and jobs of older versions are kept.",
+				Optional:    true,
+			},
+			"version_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the pipeline version uploaded last.",
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "The date and time of creation, formatted with RFC3339.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubeFlowPipelinesPipelineCreate(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	name := resourceData.Get("name").(string)
+
+	var pipeline *pipelines.Pipeline
+	var err error
+	if v, ok := resourceData.GetOk("url"); ok {
+		log.Printf("[INFO] Creating pipeline %s from %s", name, v)
+		pipeline, err = cli.CreatePipeline(ctx, &pipelines.Pipeline{
+			Name: name,
+			URL:  &pipelines.URL{PipelineURL: v.(string)},
+		})
+	} else {
+		var file []byte
+		file, err = pipelineFile(resourceData)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Uploading pipeline %s", name)
+		pipeline, err = cli.UploadPipeline(ctx, name, resourceData.Get("description").(string), pipelineFileName(resourceData), bytes.NewReader(file))
+	}
+	if err != nil {
+		return fmt.Errorf("unable to create pipeline %s: %s", name, err)
+	}
+	resourceData.SetId(pipeline.ID)
+
+	if pipeline.DefaultVersion != nil {
+		if err := resourceData.Set("version_id", pipeline.DefaultVersion.ID); err != nil {
+			return err
+		}
+	}
+	// The API names the version created with the pipeline after the
+	// pipeline, so a named version takes its place as the default.
+	if _, ok := resourceData.GetOk("version"); ok {
+		if err := createPipelineVersion(resourceData, meta); err != nil {
+			return err
+		}
+		versionID := resourceData.Get("version_id").(string)
+		if err := cli.UpdatePipelineDefaultVersion(ctx, pipeline.ID, versionID); err != nil {
+			return fmt.Errorf("unable to make version %s the default of pipeline %s: %s", versionID, pipeline.ID, err)
+		}
+		if pipeline.DefaultVersion != nil {
+			log.Printf("[INFO] Deleting version %s of pipeline %s, replaced by version %s", pipeline.DefaultVersion.ID, pipeline.ID, versionID)
+			if err := cli.DeletePipelineVersion(ctx, pipeline.DefaultVersion.ID); err != nil && !pipelines.IsNotFound(err) {
+				return fmt.Errorf("unable to delete version %s of pipeline %s: %s", pipeline.DefaultVersion.ID, pipeline.ID, err)
+			}
+		}
+	}
+
+	return resourceKubeFlowPipelinesPipelineRead(resourceData, meta)
+}
+
+func resourceKubeFlowPipelinesPipelineRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	log.Printf("[INFO] Reading pipeline %s", resourceData.Id())
+	pipeline, err := cli.GetPipeline(ctx, resourceData.Id())
+	if err != nil {
+		if pipelines.IsNotFound(err) {
+			log.Printf("[DEBUG] Pipeline %s no longer exists", resourceData.Id())
+			resourceData.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	if err := resourceData.Set("name", pipeline.Name); err != nil {
+		return err
+	}
+	if pipeline.URL == nil {
+		if err := resourceData.Set("description", pipeline.Description); err != nil {
+			return err
+		}
+	}
+	if pipeline.CreatedAt != nil {
+		if err := resourceData.Set("created_at", pipeline.CreatedAt.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+
+	// Fall back to the default version when the last uploaded one is gone.
+	if versionID := resourceData.Get("version_id").(string); versionID != "" {
+		if _, err := cli.GetPipelineVersion(ctx, versionID); err == nil || !pipelines.IsNotFound(err) {
+			return err
+		}
+		log.Printf("[DEBUG] Version %s of pipeline %s no longer exists", versionID, pipeline.ID)
+	}
+	if pipeline.DefaultVersion != nil {
+		return resourceData.Set("version_id", pipeline.DefaultVersion.ID)
+	}
+	return resourceData.Set("version_id", "")
+}
+
+func resourceKubeFlowPipelinesPipelineUpdate(resourceData *schema.ResourceData, meta interface{}) error {
+	if resourceData.HasChanges("url", "file_base64", "file_format", "version") {
+		if err := createPipelineVersion(resourceData, meta); err != nil {
+			return err
+		}
+	}
+	return resourceKubeFlowPipelinesPipelineRead(resourceData, meta)
+}
+
+func resourceKubeFlowPipelinesPipelineDelete(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	log.Printf("[INFO] Deleting pipeline %s", resourceData.Id())
+	if err := cli.DeletePipeline(ctx, resourceData.Id()); err != nil && !pipelines.IsNotFound(err) {
+		return err
+	}
+	resourceData.SetId("")
+	return nil
+}
+
+// createPipelineVersion uploads the configured definition as a new version of
+// the pipeline and records it in version_id.
+func createPipelineVersion(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutUpdate)
+	defer cancel()
+
+	name := resourceData.Get("version").(string)
+	if name == "" {
+		name = fmt.Sprintf("%s-%s", resourceData.Get("name").(string), time.Now().UTC().Format("20060102150405"))
+	}
+
+	var version *pipelines.PipelineVersion
+	var err error
+	if v, ok := resourceData.GetOk("url"); ok {
+		log.Printf("[INFO] Creating version %s of pipeline %s from %s", name, resourceData.Id(), v)
+		version, err = cli.CreatePipelineVersion(ctx, &pipelines.PipelineVersion{
+			Name:       name,
+			PackageURL: &pipelines.URL{PipelineURL: v.(string)},
+			ResourceReferences: []pipelines.ResourceReference{{
+				Key:          &pipelines.ResourceKey{Type: pipelines.ResourceTypePipeline, ID: resourceData.Id()},
+				Relationship: pipelines.RelationshipOwner,
+			}},
+		})
+	} else {
+		var file []byte
+		file, err = pipelineFile(resourceData)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Uploading version %s of pipeline %s", name, resourceData.Id())
+		version, err = cli.UploadPipelineVersion(ctx, resourceData.Id(), name, pipelineFileName(resourceData), bytes.NewReader(file))
+	}
+	if err != nil {
+		return fmt.Errorf("unable to create version %s of pipeline %s: %s", name, resourceData.Id(), err)
+	}
+	return resourceData.Set("version_id", version.ID)
+}
+
+func pipelineFile(resourceData *schema.ResourceData) ([]byte, error) {
+	file, err := base64.StdEncoding.DecodeString(resourceData.Get("file_base64").(string))
+	if err != nil {
+		return nil, fmt.Errorf("file_base64 is not valid base64: %s", err)
+	}
+	return file, nil
+}
+
+// pipelineFileName returns the name to upload the definition as, since the
+// API tells the formats apart by extension.
+func pipelineFileName(resourceData *schema.ResourceData) string {
+	return resourceData.Get("name").(string) + "." + resourceData.Get("file_format").(string)
+}
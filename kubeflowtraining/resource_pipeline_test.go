@@ -0,0 +1,77 @@
+package kubeflowtraining
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+func TestResourcePipelineCreate(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.URL.Query().Get("name"))
+		switch r.URL.Path {
+		case "/apis/v1beta1/pipelines/upload":
+			io.WriteString(w, `{"id": "p1", "name": "example", "default_version": {"id": "v0", "name": "example"}}`)
+		case "/apis/v1beta1/pipelines/upload_version":
+			if v := r.URL.Query().Get("pipelineid"); v != "p1" {
+				t.Errorf("unexpected pipeline id %q", v)
+			}
+			io.WriteString(w, `{"id": "v1", "name": "v0.0.1"}`)
+		case "/apis/v1beta1/pipelines/p1/default_version/v1", "/apis/v1beta1/pipeline_versions/v0":
+			io.WriteString(w, `{}`)
+		case "/apis/v1beta1/pipelines/p1":
+			io.WriteString(w, `{"id": "p1", "name": "example", "description": "Description", "created_at": "2020-07-12T13:33:12Z", "default_version": {"id": "v1"}}`)
+		case "/apis/v1beta1/pipeline_versions/v1":
+			io.WriteString(w, `{"id": "v1", "name": "v0.0.1"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta := &providerMeta{pipelines: pipelines.NewClient(server.URL, server.Client())}
+	resourceData := schema.TestResourceDataRaw(t, resourceKubeFlowPipelinesPipeline().Schema, map[string]interface{}{
+		"name":        "example",
+		"description": "Description",
+		"file_base64": base64.StdEncoding.EncodeToString([]byte("kind: Workflow")),
+		"file_format": "yaml",
+		"version":     "v0.0.1",
+	})
+	if err := resourceKubeFlowPipelinesPipelineCreate(resourceData, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if resourceData.Id() != "p1" {
+		t.Fatalf("expected id p1, got %q", resourceData.Id())
+	}
+	if v := resourceData.Get("version_id").(string); v != "v1" {
+		t.Fatalf("expected version_id v1, got %q", v)
+	}
+	if v := resourceData.Get("created_at").(string); v != "2020-07-12T13:33:12Z" {
+		t.Fatalf("unexpected created_at %q", v)
+	}
+	expected := []string{
+		"POST /apis/v1beta1/pipelines/upload example",
+		// The version named after the pipeline is replaced by the named one.
+		"POST /apis/v1beta1/pipelines/upload_version v0.0.1",
+		"POST /apis/v1beta1/pipelines/p1/default_version/v1 ",
+		"DELETE /apis/v1beta1/pipeline_versions/v0 ",
+		"GET /apis/v1beta1/pipelines/p1 ",
+		"GET /apis/v1beta1/pipeline_versions/v1 ",
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("expected requests %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Fatalf("expected requests %v, got %v", expected, requests)
+		}
+	}
+}
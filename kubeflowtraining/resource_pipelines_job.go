@@ -0,0 +1,371 @@
+package kubeflowtraining
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
+)
+
+func resourceKubeFlowPipelinesJob() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubeFlowPipelinesJobCreate,
+		Read:   resourceKubeFlowPipelinesJobRead,
+		Update: resourceKubeFlowPipelinesJobUpdate,
+		Delete: resourceKubeFlowPipelinesJobDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the job.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the job.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"experiment_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the experiment the runs of the job belong to. Jobs without one use the default experiment.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"service_account": {
+				Type:        schema.TypeString,
+				Description: "Kubernetes service account the pods of the job's runs use. Defaults to the service account of the Kubeflow Pipelines installation.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"pipeline_spec": pipelineSpecSchema("The pipeline version the job runs and its parameters."),
+			"enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the job schedules runs. Disabling the job in the UI shows up as a diff.",
+				Optional:    true,
+				Default:     true,
+			},
+			"max_concurrency": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of concurrent runs of the job, between 1 and 10.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 10),
+			},
+			"no_catchup": {
+				Type:        schema.TypeBool,
+				Description: "Whether the job only schedules the latest interval when behind schedule, instead of catching up on each past interval.",
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+			},
+			"trigger": {
+				Type:        schema.TypeList,
+				Description: "When the job schedules runs.",
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cron_schedule": {
+							Type:         schema.TypeList,
+							Description:  "Schedule runs with a cron expression.",
+							Optional:     true,
+							ForceNew:     true,
+							MaxItems:     1,
+							ExactlyOneOf: []string{"trigger.0.cron_schedule", "trigger.0.periodic_schedule"},
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start_time": scheduleTimeSchema("The start time of the schedule, formatted with RFC3339."),
+									"end_time":   scheduleTimeSchema("The end time of the schedule, formatted with RFC3339."),
+									"cron": {
+										Type:         schema.TypeString,
+										Description:  "Cron expression in the quartz format, starting with seconds, e.g. `0 0/10 * * * ?`.",
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: utils.ValidateCronExpression,
+									},
+								},
+							},
+						},
+						"periodic_schedule": {
+							Type:         schema.TypeList,
+							Description:  "Schedule runs at a fixed interval.",
+							Optional:     true,
+							ForceNew:     true,
+							MaxItems:     1,
+							ExactlyOneOf: []string{"trigger.0.cron_schedule", "trigger.0.periodic_schedule"},
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start_time": scheduleTimeSchema("The start time of the schedule, formatted with RFC3339."),
+									"end_time":   scheduleTimeSchema("The end time of the schedule, formatted with RFC3339."),
+									"interval_seconds": {
+										Type:         schema.TypeInt,
+										Description:  "Interval between runs in seconds.",
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "Status of the job.",
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "The date and time of creation, formatted with RFC3339.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func scheduleTimeSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Description:      description,
+		Required:         true,
+		ForceNew:         true,
+		ValidateFunc:     validation.IsRFC3339Time,
+		DiffSuppressFunc: suppressEquivalentRFC3339Time,
+	}
+}
+
+// suppressEquivalentRFC3339Time suppresses diffs of times the API returns in
+// another time zone.
+func suppressEquivalentRFC3339Time(k, old, new string, d *schema.ResourceData) bool {
+	o, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	n, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return o.Equal(n)
+}
+
+func resourceKubeFlowPipelinesJobCreate(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	job, err := expandPipelinesJob(resourceData)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Creating job %s", job.Name)
+	out, err := cli.CreateJob(ctx, job)
+	if err != nil {
+		return fmt.Errorf("unable to create job %s: %s", job.Name, err)
+	}
+	resourceData.SetId(out.ID)
+
+	return resourceKubeFlowPipelinesJobRead(resourceData, meta)
+}
+
+func resourceKubeFlowPipelinesJobRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	log.Printf("[INFO] Reading job %s", resourceData.Id())
+	job, err := cli.GetJob(ctx, resourceData.Id())
+	if err != nil {
+		if pipelines.IsNotFound(err) {
+			log.Printf("[DEBUG] Job %s no longer exists", resourceData.Id())
+			resourceData.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	if err := resourceData.Set("name", job.Name); err != nil {
+		return err
+	}
+	if err := resourceData.Set("description", job.Description); err != nil {
+		return err
+	}
+	if err := resourceData.Set("service_account", job.ServiceAccount); err != nil {
+		return err
+	}
+	spec, experimentID := flattenPipelineSpec(job.PipelineSpec, job.ResourceReferences)
+	if err := resourceData.Set("pipeline_spec", spec); err != nil {
+		return err
+	}
+	if err := resourceData.Set("experiment_id", experimentID); err != nil {
+		return err
+	}
+
+	if enabled := resourceData.Get("enabled").(bool); enabled && !job.Enabled {
+		log.Printf("[WARN] Job %s was disabled outside of Terraform", resourceData.Id())
+	}
+	if err := resourceData.Set("enabled", job.Enabled); err != nil {
+		return err
+	}
+	if err := resourceData.Set("max_concurrency", int(job.MaxConcurrency)); err != nil {
+		return err
+	}
+	if err := resourceData.Set("no_catchup", job.NoCatchup); err != nil {
+		return err
+	}
+	if err := resourceData.Set("trigger", flattenJobTrigger(job.Trigger)); err != nil {
+		return err
+	}
+	if err := resourceData.Set("status", job.Status); err != nil {
+		return err
+	}
+	if job.CreatedAt != nil {
+		return resourceData.Set("created_at", job.CreatedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func resourceKubeFlowPipelinesJobUpdate(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutUpdate)
+	defer cancel()
+
+	// Everything but enabled forces a new job, as the API cannot update jobs.
+	if resourceData.HasChange("enabled") {
+		var err error
+		if resourceData.Get("enabled").(bool) {
+			log.Printf("[INFO] Enabling job %s", resourceData.Id())
+			err = cli.EnableJob(ctx, resourceData.Id())
+		} else {
+			log.Printf("[INFO] Disabling job %s", resourceData.Id())
+			err = cli.DisableJob(ctx, resourceData.Id())
+		}
+		if err != nil {
+			return fmt.Errorf("unable to update job %s: %s", resourceData.Id(), err)
+		}
+	}
+	return resourceKubeFlowPipelinesJobRead(resourceData, meta)
+}
+
+func resourceKubeFlowPipelinesJobDelete(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	log.Printf("[INFO] Deleting job %s", resourceData.Id())
+	if err := cli.DeleteJob(ctx, resourceData.Id()); err != nil && !pipelines.IsNotFound(err) {
+		return err
+	}
+	resourceData.SetId("")
+	return nil
+}
+
+func expandPipelinesJob(resourceData *schema.ResourceData) (*pipelines.Job, error) {
+	spec, refs := expandPipelineSpec(resourceData)
+	trigger, err := expandJobTrigger(resourceData.Get("trigger").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return &pipelines.Job{
+		Name:               resourceData.Get("name").(string),
+		Description:        resourceData.Get("description").(string),
+		ServiceAccount:     resourceData.Get("service_account").(string),
+		PipelineSpec:       spec,
+		ResourceReferences: refs,
+		MaxConcurrency:     int64(resourceData.Get("max_concurrency").(int)),
+		Trigger:            trigger,
+		Enabled:            resourceData.Get("enabled").(bool),
+		NoCatchup:          resourceData.Get("no_catchup").(bool),
+	}, nil
+}
+
+func expandJobTrigger(l []interface{}) (*pipelines.Trigger, error) {
+	trigger := &pipelines.Trigger{}
+	if len(l) == 0 || l[0] == nil {
+		return trigger, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["cron_schedule"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		cron := v[0].(map[string]interface{})
+		start, end, err := expandScheduleTimes(cron)
+		if err != nil {
+			return nil, err
+		}
+		trigger.CronSchedule = &pipelines.CronSchedule{
+			StartTime: start,
+			EndTime:   end,
+			Cron:      cron["cron"].(string),
+		}
+	}
+	if v, ok := in["periodic_schedule"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		periodic := v[0].(map[string]interface{})
+		start, end, err := expandScheduleTimes(periodic)
+		if err != nil {
+			return nil, err
+		}
+		trigger.PeriodicSchedule = &pipelines.PeriodicSchedule{
+			StartTime:      start,
+			EndTime:        end,
+			IntervalSecond: int64(periodic["interval_seconds"].(int)),
+		}
+	}
+	return trigger, nil
+}
+
+func expandScheduleTimes(in map[string]interface{}) (*time.Time, *time.Time, error) {
+	start, err := time.Parse(time.RFC3339, in["start_time"].(string))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid start_time: %s", err)
+	}
+	end, err := time.Parse(time.RFC3339, in["end_time"].(string))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid end_time: %s", err)
+	}
+	return &start, &end, nil
+}
+
+func flattenJobTrigger(in *pipelines.Trigger) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+	trigger := map[string]interface{}{
+		"cron_schedule":     []interface{}{},
+		"periodic_schedule": []interface{}{},
+	}
+	if in.CronSchedule != nil {
+		cron := flattenScheduleTimes(in.CronSchedule.StartTime, in.CronSchedule.EndTime)
+		cron["cron"] = in.CronSchedule.Cron
+		trigger["cron_schedule"] = []interface{}{cron}
+	}
+	if in.PeriodicSchedule != nil {
+		periodic := flattenScheduleTimes(in.PeriodicSchedule.StartTime, in.PeriodicSchedule.EndTime)
+		periodic["interval_seconds"] = int(in.PeriodicSchedule.IntervalSecond)
+		trigger["periodic_schedule"] = []interface{}{periodic}
+	}
+	return []interface{}{trigger}
+}
+
+func flattenScheduleTimes(start *time.Time, end *time.Time) map[string]interface{} {
+	out := map[string]interface{}{}
+	if start != nil {
+		out["start_time"] = start.Format(time.RFC3339)
+	}
+	if end != nil {
+		out["end_time"] = end.Format(time.RFC3339)
+	}
+	return out
+}
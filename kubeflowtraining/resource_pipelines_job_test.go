@@ -0,0 +1,91 @@
+package kubeflowtraining
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+func TestResourcePipelinesJobReadsDisabled(t *testing.T) {
+	enabled := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /apis/v1beta1/jobs":
+			body, _ := io.ReadAll(r.Body)
+			var job map[string]interface{}
+			if err := json.Unmarshal(body, &job); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if job["max_concurrency"] != "2" || job["enabled"] != true {
+				t.Errorf("unexpected job %s", body)
+			}
+			io.WriteString(w, `{"id": "j1"}`)
+		case "GET /apis/v1beta1/jobs/j1":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":              "j1",
+				"name":            "example",
+				"max_concurrency": "2",
+				"enabled":         enabled,
+				"no_catchup":      true,
+				"trigger": map[string]interface{}{
+					"cron_schedule": map[string]interface{}{
+						"start_time": "2020-06-22T22:00:00Z",
+						"end_time":   "2030-06-23T00:00:00Z",
+						"cron":       "0 0/10 * * * ?",
+					},
+				},
+				"resource_references": []interface{}{
+					map[string]interface{}{"key": map[string]interface{}{"type": "PIPELINE_VERSION", "id": "v1"}, "relationship": "CREATOR"},
+				},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta := &providerMeta{pipelines: pipelines.NewClient(server.URL, server.Client())}
+	resourceData := schema.TestResourceDataRaw(t, resourceKubeFlowPipelinesJob().Schema, map[string]interface{}{
+		"name":            "example",
+		"max_concurrency": 2,
+		"pipeline_spec":   []interface{}{map[string]interface{}{"pipeline_version_id": "v1"}},
+		"trigger": []interface{}{map[string]interface{}{
+			"cron_schedule": []interface{}{map[string]interface{}{
+				"start_time": "2020-06-23T00:00:00+02:00",
+				"end_time":   "2030-06-23T00:00:00Z",
+				"cron":       "0 0/10 * * * ?",
+			}},
+		}},
+	})
+	if err := resourceKubeFlowPipelinesJobCreate(resourceData, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v := resourceData.Get("trigger.0.cron_schedule.0.cron").(string); v != "0 0/10 * * * ?" {
+		t.Fatalf("unexpected cron %q", v)
+	}
+
+	// Someone disables the job in the UI.
+	enabled = false
+	if err := resourceKubeFlowPipelinesJobRead(resourceData, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resourceData.Get("enabled").(bool) {
+		t.Fatal("expected the disabled job to be read as disabled")
+	}
+}
+
+func TestSuppressEquivalentRFC3339Time(t *testing.T) {
+	if !suppressEquivalentRFC3339Time("", "2020-06-22T22:00:00Z", "2020-06-23T00:00:00+02:00", nil) {
+		t.Fatal("expected equal times to be suppressed")
+	}
+	if suppressEquivalentRFC3339Time("", "2020-06-22T22:00:00Z", "2020-06-23T00:00:00Z", nil) {
+		t.Fatal("expected different times not to be suppressed")
+	}
+}
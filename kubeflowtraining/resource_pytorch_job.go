@@ -1,21 +1,22 @@
 package kubeflowtraining
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
-	corev1 "k8s.io/api/core/v1"
 
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/pytorch_job"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 func resourceKubeFlowPyTorchJob() *schema.Resource {
@@ -28,6 +29,7 @@ func resourceKubeFlowPyTorchJob() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: customizeDiffSchedulingPolicy,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
@@ -38,111 +40,88 @@ func resourceKubeFlowPyTorchJob() *schema.Resource {
 
 func resourceKubeFlowPyTorchJobCreate(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
 
 	ptj, err := pytorch_job.FromResourceData(resourceData)
 	if err != nil {
 		return err
 	}
-
-	log.Printf("[INFO] Creating new PyTorchJob: %#v", ptj)
-	if err := cli.CreatePyTorchJob(ptj); err != nil {
+	if err := checkJobQuota(ctx, cli, resourceData, "PyTorchJob", ptj.Namespace, ptj.Name, ptj); err != nil {
 		return err
 	}
-	log.Printf("[INFO] Submitted new PyTorchJob: %#v", ptj)
+
+	log.Printf("[INFO] Creating new PyTorchJob: %s", utils.Redacted(ptj))
+	if err := cli.CreatePyTorchJob(ctx, ptj); err != nil {
+		existing, err := adoptExistingJob(ctx, cli, resourceData, "PyTorchJob", ptj.Namespace, ptj.Name, ptj, err)
+		if err != nil {
+			return err
+		}
+		ptj = existing.(*kubeflowv1.PyTorchJob)
+	}
+	log.Printf("[INFO] Submitted new PyTorchJob: %s", utils.Redacted(ptj))
 	if err := pytorch_job.ToResourceData(*ptj, resourceData); err != nil {
 		return err
 	}
 	resourceData.SetId(utils.BuildId(ptj.ObjectMeta))
+	if !resourceData.Get("wait_for_completion").(bool) {
+		log.Printf("[INFO] Not waiting for PyTorchJob %s to complete, wait_for_completion is false", ptj.Name)
+		return nil
+	}
 
 	// Wait for PyTorchJob instance's status phase to be succeeded:
 	name := ptj.ObjectMeta.Name
 	namespace := ptj.ObjectMeta.Namespace
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Creating"},
+	progress := newJobProgress(cli, "PyTorchJob", namespace, name)
+	stateConf := &jobStateConf{
+		Pending: jobPendingStates,
 		Target:  []string{"Succeeded"},
 		Timeout: resourceData.Timeout(schema.TimeoutCreate),
 		Refresh: func() (interface{}, string, error) {
-			var err error
-			ptj, err = cli.GetPyTorchJob(namespace, name)
+			ptj, err := cli.GetPyTorchJob(ctx, namespace, name)
 			if err != nil {
 				if errors.IsNotFound(err) {
 					log.Printf("[DEBUG] PyTorchJob %s is not created yet", name)
-					return ptj, "Creating", nil
-				}
-				return ptj, "", err
-			}
-
-			if err = kubeflowv1.ValidateV1PyTorchJob(ptj); err != nil {
-				log.Printf("[DEBUG] PyTorchJob %s is not valid yet: %s", name, err)
-				return ptj, "Creating", nil
-			}
-
-			for _, c := range ptj.Status.Conditions {
-				if c.Type == commonv1.JobSucceeded && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] PyTorchJob %s is succeeded", name)
-					return ptj, "Succeeded", nil
-				}
-
-				if c.Type == commonv1.JobFailed && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] PyTorchJob %s is failed", name)
-					return ptj, "Failed", nil
-				}
-
-				if c.Type == commonv1.JobRunning && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] PyTorchJob %s is running", name)
-					return ptj, "Running", nil
-				}
-
-				if c.Type == commonv1.JobRunning && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] PyTorchJob %s is pending", name)
-					return ptj, "Pending", nil
-				}
-
-				if c.Type == commonv1.JobCreated && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] PyTorchJob %s is created", name)
-					return ptj, "Created", nil
-				}
-
-				if c.Type == commonv1.JobCreated && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] PyTorchJob %s is creating", name)
-					return ptj, "Creating", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] PyTorchJob %s is restarting", name)
-					return ptj, "Restarting", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] PyTorchJob %s is restarting", name)
-					return ptj, "Restarting", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionUnknown {
-					log.Printf("[DEBUG] PyTorchJob %s is restarting", name)
-					return ptj, "Restarting", nil
+					return nil, "Creating", nil
 				}
+				return nil, "", err
 			}
-
-			if ptj.Status.StartTime == nil {
-				log.Printf("[DEBUG] PyTorchJob %s is not started yet", name)
-				return ptj, "Creating", nil
+			return ptj, ptjState(ptj), nil
+		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return cli.WatchPyTorchJob(ctx, namespace, name, resourceVersion)
+		},
+		State: func(obj interface{}) (string, error) {
+			return ptjState(obj.(*kubeflowv1.PyTorchJob)), nil
+		},
+		Progress: func(ctx context.Context, obj interface{}, state string) {
+			var replicaStatuses map[commonv1.ReplicaType]*commonv1.ReplicaStatus
+			if ptj, ok := obj.(*kubeflowv1.PyTorchJob); ok {
+				replicaStatuses = ptj.Status.ReplicaStatuses
 			}
-
-			log.Printf("[DEBUG] PyTorchJob %s is being created", name)
-			return ptj, "Creating", nil
+			progress.log(ctx, state, replicaStatuses)
 		},
+		Describe: progress.describe,
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	obj, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
 		return fmt.Errorf("%s", err)
 	}
-	return pytorch_job.ToResourceData(*ptj, resourceData)
+	ptj = obj.(*kubeflowv1.PyTorchJob)
+
+	if err := pytorch_job.ToResourceData(*ptj, resourceData); err != nil {
+		return err
+	}
+
+	return setJobOutputs(ctx, cli, resourceData, "PyTorchJob", namespace, name)
 }
 
 func resourceKubeFlowPyTorchJobRead(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -151,18 +130,20 @@ func resourceKubeFlowPyTorchJobRead(resourceData *schema.ResourceData, meta inte
 
 	log.Printf("[INFO] Reading PyTorchJob %s", name)
 
-	ptj, err := cli.GetPyTorchJob(namespace, name)
+	ptj, err := cli.GetPyTorchJob(ctx, namespace, name)
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
-	log.Printf("[INFO] Received PyTorchJob: %#v", ptj)
+	log.Printf("[INFO] Received PyTorchJob: %s", utils.Redacted(ptj))
 
 	return pytorch_job.ToResourceData(*ptj, resourceData)
 }
 
 func resourceKubeFlowPyTorchJobUpdate(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutUpdate)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -170,57 +151,86 @@ func resourceKubeFlowPyTorchJobUpdate(resourceData *schema.ResourceData, meta in
 	}
 
 	ops := pytorch_job.AppendPatchOps("", "", resourceData, []patch.PatchOperation{})
-	data, err := ops.MarshalJSON()
-	if err != nil {
-		return fmt.Errorf("failed to marshal update operations: %s", err)
-	}
-
 	log.Printf("[INFO] Updating PyTorchJob: %s", ops)
 	out := &kubeflowv1.PyTorchJob{}
-	if err := cli.UpdatePyTorchJob(namespace, name, out, data); err != nil {
+	err = updateJob(ctx, cli, resourceData, "PyTorchJob", namespace, name, ops, func(data []byte) error {
+		return cli.UpdatePyTorchJob(ctx, namespace, name, out, data)
+	})
+	if err != nil {
 		return err
 	}
 
-	log.Printf("[INFO] Submitted updated PyTorchJob: %#v", out)
+	log.Printf("[INFO] Submitted updated PyTorchJob: %s", utils.Redacted(out))
 
 	return resourceKubeFlowPyTorchJobRead(resourceData, meta)
 }
 
 func resourceKubeFlowPyTorchJobDelete(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
 		return err
 	}
 
+	err = checkJobDestroy(resourceData, "PyTorchJob", name, func() (string, error) {
+		ptj, err := cli.GetPyTorchJob(ctx, namespace, name)
+		if err != nil {
+			return "", err
+		}
+		return ptjState(ptj), nil
+	})
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Deleting PyTorchJob: %#v", name)
-	if err := cli.DeletePyTorchJob(namespace, name); err != nil {
+	deleteOptions, waitForPods, err := kubernetes.ExpandDeleteOptions(resourceData.Get("delete_options").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err := cli.DeletePyTorchJob(ctx, namespace, name, deleteOptions); err != nil {
 		return err
 	}
 
 	// Wait for  instance to be removed:
-	stateConf := &resource.StateChangeConf{
+	stateConf := &jobStateConf{
 		Pending: []string{"Deleting"},
 		Timeout: resourceData.Timeout(schema.TimeoutDelete),
 		Refresh: func() (interface{}, string, error) {
-			ptj, err := cli.GetPyTorchJob(namespace, name)
+			ptj, err := cli.GetPyTorchJob(ctx, namespace, name)
 			if err != nil {
 				if errors.IsNotFound(err) {
 					return nil, "", nil
 				}
-				return ptj, "", err
+				return nil, "", err
 			}
 
 			log.Printf("[DEBUG] PyTorchJob %s is being deleted", ptj.GetName())
 			return ptj, "Deleting", nil
 		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return cli.WatchPyTorchJob(ctx, namespace, name, resourceVersion)
+		},
+		State: func(obj interface{}) (string, error) {
+			log.Printf("[DEBUG] PyTorchJob %s is being deleted", name)
+			return "Deleting", nil
+		},
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
 		return fmt.Errorf("%s", err)
 	}
 
+	if waitForPods {
+		log.Printf("[INFO] Waiting for pods of PyTorchJob %s to be deleted", name)
+		if err := waitForJobPodsDeleted(ctx, cli, namespace, name, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("%s", err)
+		}
+	}
+
 	log.Printf("[INFO] PyTorchJob %s deleted", name)
 
 	resourceData.SetId("")
@@ -229,6 +239,8 @@ func resourceKubeFlowPyTorchJobDelete(resourceData *schema.ResourceData, meta in
 
 func resourceKubeFlowPyTorchJobExists(resourceData *schema.ResourceData, meta interface{}) (bool, error) {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -236,7 +248,7 @@ func resourceKubeFlowPyTorchJobExists(resourceData *schema.ResourceData, meta in
 	}
 
 	log.Printf("[INFO] Checking PyTorchJob %s", name)
-	if _, err := cli.GetPyTorchJob(namespace, name); err != nil {
+	if _, err := cli.GetPyTorchJob(ctx, namespace, name); err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
 			return false, nil
 		}
@@ -245,3 +257,15 @@ func resourceKubeFlowPyTorchJobExists(resourceData *schema.ResourceData, meta in
 	}
 	return true, nil
 }
+
+// ptjState reports the state of a PyTorchJob from its status conditions.
+func ptjState(ptj *kubeflowv1.PyTorchJob) string {
+	if err := kubeflowv1.ValidateV1PyTorchJob(ptj); err != nil {
+		log.Printf("[DEBUG] PyTorchJob %s is not valid yet: %s", ptj.Name, err)
+		return "Creating"
+	}
+
+	state := jobConditionsState(ptj.Status.Conditions)
+	log.Printf("[DEBUG] PyTorchJob %s is in state %s", ptj.Name, state)
+	return state
+}
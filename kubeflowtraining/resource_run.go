@@ -0,0 +1,402 @@
+package kubeflowtraining
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+// runPollInterval is how often a run is read while waiting for it to finish.
+var runPollInterval = 10 * time.Second
+
+func resourceKubeFlowPipelinesRun() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubeFlowPipelinesRunCreate,
+		Read:   resourceKubeFlowPipelinesRunRead,
+		Update: resourceKubeFlowPipelinesRunUpdate,
+		Delete: resourceKubeFlowPipelinesRunDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the run.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Description of the run.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"experiment_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the experiment the run belongs to. Runs without one go to the default experiment.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"service_account": {
+				Type:        schema.TypeString,
+				Description: "Kubernetes service account the run's pods use. Defaults to the service account of the Kubeflow Pipelines installation.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"pipeline_spec": pipelineSpecSchema("The pipeline version to run and its parameters."),
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Description: "Wait on create until the run has finished, and fail if it did not succeed.",
+				Optional:    true,
+				Default:     false,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "Status of the run, e.g. Running, Succeeded or Failed.",
+				Computed:    true,
+			},
+			"error": {
+				Type:        schema.TypeString,
+				Description: "Error of the run, if any.",
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Description: "The date and time of creation, formatted with RFC3339.",
+				Computed:    true,
+			},
+			"finished_at": {
+				Type:        schema.TypeString,
+				Description: "The date and time the run finished, formatted with RFC3339.",
+				Computed:    true,
+			},
+			"metrics": {
+				Type:        schema.TypeMap,
+				Description: "Metrics reported by the run, by name.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"artifacts": {
+				Type:        schema.TypeList,
+				Description: "Output artifacts of the run's steps.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"step": {
+							Type:        schema.TypeString,
+							Description: "Name of the step that produced the artifact.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the artifact.",
+							Computed:    true,
+						},
+						"uri": {
+							Type:        schema.TypeString,
+							Description: "Location of the artifact in the artifact repository.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubeFlowPipelinesRunCreate(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	run := expandRun(resourceData)
+	log.Printf("[INFO] Creating run %s", run.Name)
+	detail, err := cli.CreateRun(ctx, run)
+	if err != nil {
+		return fmt.Errorf("unable to create run %s: %s", run.Name, err)
+	}
+	if detail.Run == nil {
+		return fmt.Errorf("unable to create run %s: the API returned no run", run.Name)
+	}
+	resourceData.SetId(detail.Run.ID)
+
+	if resourceData.Get("wait_for_completion").(bool) {
+		detail, err = waitForRun(ctx, cli, detail.Run.ID, resourceData.Timeout(schema.TimeoutCreate))
+		if detail != nil {
+			if err := setRun(resourceData, detail); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceKubeFlowPipelinesRunRead(resourceData, meta)
+}
+
+func resourceKubeFlowPipelinesRunRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	log.Printf("[INFO] Reading run %s", resourceData.Id())
+	detail, err := cli.GetRun(ctx, resourceData.Id())
+	if err != nil {
+		if pipelines.IsNotFound(err) {
+			log.Printf("[DEBUG] Run %s no longer exists", resourceData.Id())
+			resourceData.SetId("")
+			return nil
+		}
+		return err
+	}
+	return setRun(resourceData, detail)
+}
+
+func resourceKubeFlowPipelinesRunUpdate(resourceData *schema.ResourceData, meta interface{}) error {
+	// Only wait_for_completion can change in place, which is read on create.
+	return resourceKubeFlowPipelinesRunRead(resourceData, meta)
+}
+
+func resourceKubeFlowPipelinesRunDelete(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := pipelinesClient(meta)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	// Stop the run's workflow rather than leaving its pods behind.
+	if status := resourceData.Get("status").(string); !isRunFinished(status) {
+		log.Printf("[INFO] Terminating run %s", resourceData.Id())
+		if err := cli.TerminateRun(ctx, resourceData.Id()); err != nil && !pipelines.IsNotFound(err) {
+			return err
+		}
+	}
+
+	log.Printf("[INFO] Deleting run %s", resourceData.Id())
+	if err := cli.DeleteRun(ctx, resourceData.Id()); err != nil && !pipelines.IsNotFound(err) {
+		return err
+	}
+	resourceData.SetId("")
+	return nil
+}
+
+func expandRun(resourceData *schema.ResourceData) *pipelines.Run {
+	spec, refs := expandPipelineSpec(resourceData)
+	return &pipelines.Run{
+		Name:               resourceData.Get("name").(string),
+		Description:        resourceData.Get("description").(string),
+		ServiceAccount:     resourceData.Get("service_account").(string),
+		PipelineSpec:       spec,
+		ResourceReferences: refs,
+	}
+}
+
+// setRun sets the attributes of the run resource from detail.
+func setRun(resourceData *schema.ResourceData, detail *pipelines.RunDetail) error {
+	run := detail.Run
+	if run == nil {
+		return fmt.Errorf("the API returned no run for %s", resourceData.Id())
+	}
+
+	if err := resourceData.Set("name", run.Name); err != nil {
+		return err
+	}
+	if err := resourceData.Set("description", run.Description); err != nil {
+		return err
+	}
+	if err := resourceData.Set("service_account", run.ServiceAccount); err != nil {
+		return err
+	}
+
+	spec, experimentID := flattenPipelineSpec(run.PipelineSpec, run.ResourceReferences)
+	if err := resourceData.Set("experiment_id", experimentID); err != nil {
+		return err
+	}
+	if err := resourceData.Set("pipeline_spec", spec); err != nil {
+		return err
+	}
+
+	if err := resourceData.Set("status", run.Status); err != nil {
+		return err
+	}
+	if err := resourceData.Set("error", run.Error); err != nil {
+		return err
+	}
+	if run.CreatedAt != nil {
+		if err := resourceData.Set("created_at", run.CreatedAt.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	finishedAt := ""
+	// The API reports the zero time for runs that have not finished.
+	if run.FinishedAt != nil && run.FinishedAt.Unix() > 0 {
+		finishedAt = run.FinishedAt.Format(time.RFC3339)
+	}
+	if err := resourceData.Set("finished_at", finishedAt); err != nil {
+		return err
+	}
+
+	metrics := map[string]interface{}{}
+	for _, m := range run.Metrics {
+		metrics[m.Name] = strconv.FormatFloat(m.NumberValue, 'g', -1, 64)
+	}
+	if err := resourceData.Set("metrics", metrics); err != nil {
+		return err
+	}
+
+	var artifacts []interface{}
+	if detail.PipelineRuntime != nil {
+		var err error
+		artifacts, err = flattenRunArtifacts(detail.PipelineRuntime.WorkflowManifest)
+		if err != nil {
+			return err
+		}
+	}
+	return resourceData.Set("artifacts", artifacts)
+}
+
+// workflowStatus is the part of an Argo workflow holding output artifacts.
+type workflowStatus struct {
+	Status struct {
+		Nodes map[string]struct {
+			DisplayName string `json:"displayName"`
+			Outputs     *struct {
+				Artifacts []struct {
+					Name string `json:"name"`
+					S3   *struct {
+						Bucket string `json:"bucket"`
+						Key    string `json:"key"`
+					} `json:"s3"`
+					GCS *struct {
+						Bucket string `json:"bucket"`
+						Key    string `json:"key"`
+					} `json:"gcs"`
+				} `json:"artifacts"`
+			} `json:"outputs"`
+		} `json:"nodes"`
+	} `json:"status"`
+}
+
+// flattenRunArtifacts lists the output artifacts of the workflow manifest of
+// a run, ordered by step and name so that the list is stable across reads.
+func flattenRunArtifacts(manifest string) ([]interface{}, error) {
+	if manifest == "" {
+		return []interface{}{}, nil
+	}
+	var workflow workflowStatus
+	if err := json.Unmarshal([]byte(manifest), &workflow); err != nil {
+		return nil, fmt.Errorf("unable to parse the workflow of the run: %s", err)
+	}
+
+	artifacts := []interface{}{}
+	for _, node := range workflow.Status.Nodes {
+		if node.Outputs == nil {
+			continue
+		}
+		for _, a := range node.Outputs.Artifacts {
+			uri := ""
+			switch {
+			case a.S3 != nil:
+				uri = artifactURI("s3", a.S3.Bucket, a.S3.Key)
+			case a.GCS != nil:
+				uri = artifactURI("gs", a.GCS.Bucket, a.GCS.Key)
+			}
+			artifacts = append(artifacts, map[string]interface{}{
+				"step": node.DisplayName,
+				"name": a.Name,
+				"uri":  uri,
+			})
+		}
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		a, b := artifacts[i].(map[string]interface{}), artifacts[j].(map[string]interface{})
+		if a["step"] != b["step"] {
+			return a["step"].(string) < b["step"].(string)
+		}
+		return a["name"].(string) < b["name"].(string)
+	})
+	return artifacts, nil
+}
+
+// artifactURI returns the URI of an artifact, or only its key when it is
+// stored in the default bucket of the artifact repository.
+func artifactURI(scheme string, bucket string, key string) string {
+	if bucket == "" {
+		return key
+	}
+	return fmt.Sprintf("%s://%s/%s", scheme, bucket, key)
+}
+
+// waitForRun polls the run until it reaches a terminal status. A run that
+// did not succeed is an error; the last read run is returned either way.
+func waitForRun(ctx context.Context, cli pipelines.Client, id string, timeout time.Duration) (*pipelines.RunDetail, error) {
+	var last *pipelines.RunDetail
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Pending", "Running"},
+		Target: []string{
+			pipelines.RunStatusSucceeded,
+			pipelines.RunStatusFailed,
+			pipelines.RunStatusError,
+			pipelines.RunStatusSkipped,
+			pipelines.RunStatusTerminated,
+		},
+		Timeout:      timeout,
+		PollInterval: runPollInterval,
+		Refresh: func() (interface{}, string, error) {
+			detail, err := cli.GetRun(ctx, id)
+			if err != nil {
+				return nil, "", err
+			}
+			last = detail
+			status := ""
+			if detail.Run != nil {
+				status = detail.Run.Status
+			}
+			if isRunFinished(status) {
+				return detail, status, nil
+			}
+			if status == "Running" {
+				return detail, status, nil
+			}
+			// The run has no status until its workflow was scheduled.
+			return detail, "Pending", nil
+		},
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return last, fmt.Errorf("error waiting for run %s: %s", id, err)
+	}
+
+	switch last.Run.Status {
+	case pipelines.RunStatusSucceeded, pipelines.RunStatusSkipped:
+		return last, nil
+	}
+	if last.Run.Error != "" {
+		return last, fmt.Errorf("run %s finished with status %s: %s", id, last.Run.Status, last.Run.Error)
+	}
+	return last, fmt.Errorf("run %s finished with status %s", id, last.Run.Status)
+}
+
+// isRunFinished reports whether a run with status no longer changes.
+func isRunFinished(status string) bool {
+	switch status {
+	case pipelines.RunStatusSucceeded, pipelines.RunStatusFailed, pipelines.RunStatusError,
+		pipelines.RunStatusSkipped, pipelines.RunStatusTerminated:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package kubeflowtraining
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/pipelines"
+)
+
+func TestResourceRunCreateWaits(t *testing.T) {
+	defer func(interval time.Duration) { runPollInterval = interval }(runPollInterval)
+	runPollInterval = time.Millisecond
+
+	workflow := `{"status": {"nodes": {"n2": {"displayName": "train", "outputs": {"artifacts": [{"name": "model", "s3": {"bucket": "mlpipeline", "key": "artifacts/model.tgz"}}]}}, "n1": {"displayName": "prepare", "outputs": {"artifacts": [{"name": "data", "s3": {"key": "artifacts/data.tgz"}}]}}}}}`
+	reads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/apis/v1beta1/runs":
+			var run pipelines.Run
+			if err := json.NewDecoder(r.Body).Decode(&run); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(run.ResourceReferences) != 2 || run.ResourceReferences[0].Key.ID != "v1" || run.ResourceReferences[1].Key.ID != "e1" {
+				t.Errorf("unexpected resource references %+v", run.ResourceReferences)
+			}
+			if len(run.PipelineSpec.Parameters) != 1 || run.PipelineSpec.Parameters[0].Name != "epochs" {
+				t.Errorf("unexpected parameters %+v", run.PipelineSpec.Parameters)
+			}
+			io.WriteString(w, `{"run": {"id": "r1", "name": "example"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/apis/v1beta1/runs/r1":
+			reads++
+			status := "Running"
+			if reads > 1 {
+				status = "Succeeded"
+			}
+			detail := map[string]interface{}{
+				"run": map[string]interface{}{
+					"id":              "r1",
+					"name":            "example",
+					"status":          status,
+					"service_account": "pipeline-runner",
+					"pipeline_spec":   map[string]interface{}{"parameters": []interface{}{map[string]interface{}{"name": "epochs", "value": "3"}}},
+					"resource_references": []interface{}{
+						map[string]interface{}{"key": map[string]interface{}{"type": "PIPELINE_VERSION", "id": "v1"}, "relationship": "CREATOR"},
+						map[string]interface{}{"key": map[string]interface{}{"type": "EXPERIMENT", "id": "e1"}, "relationship": "OWNER"},
+					},
+					"finished_at": "1970-01-01T00:00:00Z",
+					"metrics":     []interface{}{map[string]interface{}{"name": "accuracy", "number_value": 0.9}},
+				},
+				"pipeline_runtime": map[string]interface{}{"workflow_manifest": workflow},
+			}
+			json.NewEncoder(w).Encode(detail)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	meta := &providerMeta{pipelines: pipelines.NewClient(server.URL, server.Client())}
+	resourceData := schema.TestResourceDataRaw(t, resourceKubeFlowPipelinesRun().Schema, map[string]interface{}{
+		"name":          "example",
+		"experiment_id": "e1",
+		"pipeline_spec": []interface{}{map[string]interface{}{
+			"pipeline_version_id": "v1",
+			"parameters":          map[string]interface{}{"epochs": "3"},
+		}},
+		"wait_for_completion": true,
+	})
+	if err := resourceKubeFlowPipelinesRunCreate(resourceData, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v := resourceData.Get("status").(string); v != "Succeeded" {
+		t.Fatalf("expected status Succeeded, got %q", v)
+	}
+	if v := resourceData.Get("finished_at").(string); v != "" {
+		t.Fatalf("expected no finished_at, got %q", v)
+	}
+	if v := resourceData.Get("metrics.accuracy").(string); v != "0.9" {
+		t.Fatalf("expected accuracy 0.9, got %q", v)
+	}
+	expected := []map[string]interface{}{
+		{"step": "prepare", "name": "data", "uri": "artifacts/data.tgz"},
+		{"step": "train", "name": "model", "uri": "s3://mlpipeline/artifacts/model.tgz"},
+	}
+	artifacts := resourceData.Get("artifacts").([]interface{})
+	if len(artifacts) != len(expected) {
+		t.Fatalf("expected artifacts %v, got %v", expected, artifacts)
+	}
+	for i := range expected {
+		for k, v := range expected[i] {
+			if artifacts[i].(map[string]interface{})[k] != v {
+				t.Fatalf("expected artifacts %v, got %v", expected, artifacts)
+			}
+		}
+	}
+}
@@ -1,20 +1,21 @@
 package kubeflowtraining
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
 	tf_job "github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/tensorflow_job"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 func resourceKubeFlowTFJob() *schema.Resource {
@@ -37,106 +38,85 @@ func resourceKubeFlowTFJob() *schema.Resource {
 
 func resourceKubeFlowTFJobCreate(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
 
 	tfj, err := tf_job.FromResourceData(resourceData)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("[INFO] Creating new TFJob: %#v", tfj)
-	if err := cli.CreateTFJob(tfj); err != nil {
-		return err
+	log.Printf("[INFO] Creating new TFJob: %s", utils.Redacted(tfj))
+	if err := cli.CreateTFJob(ctx, tfj); err != nil {
+		existing, err := adoptExistingJob(ctx, cli, resourceData, "TFJob", tfj.Namespace, tfj.Name, tfj, err)
+		if err != nil {
+			return err
+		}
+		tfj = existing.(*kubeflowv1.TFJob)
 	}
-	log.Printf("[INFO] Submitted new TFJob: %#v", tfj)
+	log.Printf("[INFO] Submitted new TFJob: %s", utils.Redacted(tfj))
 	if err := tf_job.ToResourceData(*tfj, resourceData); err != nil {
 		return err
 	}
 	resourceData.SetId(utils.BuildId(tfj.ObjectMeta))
+	if !resourceData.Get("wait_for_completion").(bool) {
+		log.Printf("[INFO] Not waiting for TFJob %s to complete, wait_for_completion is false", tfj.Name)
+		return nil
+	}
 
 	// Wait for TFJob instance's status phase to be succeeded:
 	name := tfj.ObjectMeta.Name
 	namespace := tfj.ObjectMeta.Namespace
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Creating"},
+	progress := newJobProgress(cli, "TFJob", namespace, name)
+	stateConf := &jobStateConf{
+		Pending: jobPendingStates,
 		Target:  []string{"Succeeded"},
 		Timeout: resourceData.Timeout(schema.TimeoutCreate),
 		Refresh: func() (interface{}, string, error) {
-			var err error
-			tfj, err = cli.GetTFJob(namespace, name)
+			tfj, err := cli.GetTFJob(ctx, namespace, name)
 			if err != nil {
 				if errors.IsNotFound(err) {
 					log.Printf("[DEBUG] TFJob %s is not created yet", name)
-					return tfj, "Creating", nil
+					return nil, "Creating", nil
 				}
-				return tfj, "", err
+				return nil, "", err
 			}
-
-			for _, c := range tfj.Status.Conditions {
-				if c.Type == commonv1.JobSucceeded && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] TFJob %s is succeeded", name)
-					return tfj, "Succeeded", nil
-				}
-
-				if c.Type == commonv1.JobFailed && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] TFJob %s is failed", name)
-					return tfj, "Failed", nil
-				}
-
-				if c.Type == commonv1.JobRunning && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] TFJob %s is running", name)
-					return tfj, "Running", nil
-				}
-
-				if c.Type == commonv1.JobRunning && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] TFJob %s is pending", name)
-					return tfj, "Pending", nil
-				}
-
-				if c.Type == commonv1.JobCreated && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] TFJob %s is created", name)
-					return tfj, "Created", nil
-				}
-
-				if c.Type == commonv1.JobCreated && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] TFJob %s is creating", name)
-					return tfj, "Creating", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] TFJob %s is restarting", name)
-					return tfj, "Restarting", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] TFJob %s is restarting", name)
-					return tfj, "Restarting", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionUnknown {
-					log.Printf("[DEBUG] TFJob %s is restarting", name)
-					return tfj, "Restarting", nil
-				}
-			}
-
-			if tfj.Status.StartTime == nil {
-				log.Printf("[DEBUG] TFJob %s is not started yet", name)
-				return tfj, "Creating", nil
+			return tfj, tfjState(tfj), nil
+		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return cli.WatchTFJob(ctx, namespace, name, resourceVersion)
+		},
+		State: func(obj interface{}) (string, error) {
+			return tfjState(obj.(*kubeflowv1.TFJob)), nil
+		},
+		Progress: func(ctx context.Context, obj interface{}, state string) {
+			var replicaStatuses map[commonv1.ReplicaType]*commonv1.ReplicaStatus
+			if tfj, ok := obj.(*kubeflowv1.TFJob); ok {
+				replicaStatuses = tfj.Status.ReplicaStatuses
 			}
-
-			log.Printf("[DEBUG] TFJob %s is being created", name)
-			return tfj, "Creating", nil
+			progress.log(ctx, state, replicaStatuses)
 		},
+		Describe: progress.describe,
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	obj, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
 		return fmt.Errorf("%s", err)
 	}
-	return tf_job.ToResourceData(*tfj, resourceData)
+	tfj = obj.(*kubeflowv1.TFJob)
+
+	if err := tf_job.ToResourceData(*tfj, resourceData); err != nil {
+		return err
+	}
+
+	return setJobOutputs(ctx, cli, resourceData, "TFJob", namespace, name)
 }
 
 func resourceKubeFlowTFJobRead(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -145,18 +125,20 @@ func resourceKubeFlowTFJobRead(resourceData *schema.ResourceData, meta interface
 
 	log.Printf("[INFO] Reading TFJob %s", name)
 
-	tfj, err := cli.GetTFJob(namespace, name)
+	tfj, err := cli.GetTFJob(ctx, namespace, name)
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
-	log.Printf("[INFO] Received TFJob: %#v", tfj)
+	log.Printf("[INFO] Received TFJob: %s", utils.Redacted(tfj))
 
 	return tf_job.ToResourceData(*tfj, resourceData)
 }
 
 func resourceKubeFlowTFJobUpdate(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutUpdate)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -164,57 +146,86 @@ func resourceKubeFlowTFJobUpdate(resourceData *schema.ResourceData, meta interfa
 	}
 
 	ops := tf_job.AppendPatchOps("", "", resourceData, []patch.PatchOperation{})
-	data, err := ops.MarshalJSON()
-	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
-	}
-
 	log.Printf("[INFO] Updating TFJob: %s", ops)
 	out := &kubeflowv1.TFJob{}
-	if err := cli.UpdateTFJob(namespace, name, out, data); err != nil {
+	err = updateJob(ctx, cli, resourceData, "TFJob", namespace, name, ops, func(data []byte) error {
+		return cli.UpdateTFJob(ctx, namespace, name, out, data)
+	})
+	if err != nil {
 		return err
 	}
 
-	log.Printf("[INFO] Submitted updated TFJob: %#v", out)
+	log.Printf("[INFO] Submitted updated TFJob: %s", utils.Redacted(out))
 
 	return resourceKubeFlowTFJobRead(resourceData, meta)
 }
 
 func resourceKubeFlowTFJobDelete(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
 		return err
 	}
 
+	err = checkJobDestroy(resourceData, "TFJob", name, func() (string, error) {
+		tfj, err := cli.GetTFJob(ctx, namespace, name)
+		if err != nil {
+			return "", err
+		}
+		return tfjState(tfj), nil
+	})
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Deleting TFJob: %#v", name)
-	if err := cli.DeleteTFJob(namespace, name); err != nil {
+	deleteOptions, waitForPods, err := kubernetes.ExpandDeleteOptions(resourceData.Get("delete_options").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err := cli.DeleteTFJob(ctx, namespace, name, deleteOptions); err != nil {
 		return err
 	}
 
 	// Wait for TFJob instance to be removed:
-	stateConf := &resource.StateChangeConf{
+	stateConf := &jobStateConf{
 		Pending: []string{"Deleting"},
 		Timeout: resourceData.Timeout(schema.TimeoutDelete),
 		Refresh: func() (interface{}, string, error) {
-			tfj, err := cli.GetTFJob(namespace, name)
+			tfj, err := cli.GetTFJob(ctx, namespace, name)
 			if err != nil {
 				if errors.IsNotFound(err) {
 					return nil, "", nil
 				}
-				return tfj, "", err
+				return nil, "", err
 			}
 
 			log.Printf("[DEBUG] TFJob %s is being deleted", tfj.GetName())
 			return tfj, "Deleting", nil
 		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return cli.WatchTFJob(ctx, namespace, name, resourceVersion)
+		},
+		State: func(obj interface{}) (string, error) {
+			log.Printf("[DEBUG] TFJob %s is being deleted", name)
+			return "Deleting", nil
+		},
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
 		return fmt.Errorf("%s", err)
 	}
 
+	if waitForPods {
+		log.Printf("[INFO] Waiting for pods of TFJob %s to be deleted", name)
+		if err := waitForJobPodsDeleted(ctx, cli, namespace, name, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("%s", err)
+		}
+	}
+
 	log.Printf("[INFO] TFJob %s deleted", name)
 
 	resourceData.SetId("")
@@ -223,6 +234,8 @@ func resourceKubeFlowTFJobDelete(resourceData *schema.ResourceData, meta interfa
 
 func resourceKubeFlowTFJobExists(resourceData *schema.ResourceData, meta interface{}) (bool, error) {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -230,7 +243,7 @@ func resourceKubeFlowTFJobExists(resourceData *schema.ResourceData, meta interfa
 	}
 
 	log.Printf("[INFO] Checking TFJob %s", name)
-	if _, err := cli.GetTFJob(namespace, name); err != nil {
+	if _, err := cli.GetTFJob(ctx, namespace, name); err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
 			return false, nil
 		}
@@ -239,3 +252,10 @@ func resourceKubeFlowTFJobExists(resourceData *schema.ResourceData, meta interfa
 	}
 	return true, nil
 }
+
+// tfjState reports the state of a TFJob from its status conditions.
+func tfjState(tfj *kubeflowv1.TFJob) string {
+	state := jobConditionsState(tfj.Status.Conditions)
+	log.Printf("[DEBUG] TFJob %s is in state %s", tfj.Name, state)
+	return state
+}
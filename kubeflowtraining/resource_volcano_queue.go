@@ -0,0 +1,208 @@
+package kubeflowtraining
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
+)
+
+func resourceKubeFlowVolcanoQueue() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubeFlowVolcanoQueueCreate,
+		Read:   resourceKubeFlowVolcanoQueueRead,
+		Update: resourceKubeFlowVolcanoQueueUpdate,
+		Delete: resourceKubeFlowVolcanoQueueDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"metadata": kubernetes.MetadataSchema("Queue", false),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec of the Volcano queue.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"weight": {
+							Type:         schema.TypeInt,
+							Description:  "Share of the cluster the queue gets relative to the other queues.",
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"capability": kubernetes.ResourceListSchema("Upper limit of the resources the jobs of the queue may use, e.g. cpu, memory or nvidia.com/gpu."),
+						"reclaimable": {
+							Type:        schema.TypeBool,
+							Description: "Whether other queues may reclaim the resources the queue uses beyond its share.",
+							Optional:    true,
+							Default:     true,
+						},
+					},
+				},
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Description: "State of the queue, e.g. Open or Closed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubeFlowVolcanoQueueCreate(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
+
+	queue, err := expandVolcanoQueue(resourceData)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Creating new Volcano queue: %s", queue.GetName())
+	out, err := cli.CreateVolcanoQueue(ctx, queue)
+	if err != nil {
+		return err
+	}
+	resourceData.SetId(out.GetName())
+
+	return setVolcanoQueue(resourceData, out)
+}
+
+func resourceKubeFlowVolcanoQueueRead(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
+
+	log.Printf("[INFO] Reading Volcano queue %s", resourceData.Id())
+	queue, err := cli.GetVolcanoQueue(ctx, resourceData.Id())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[DEBUG] Volcano queue %s no longer exists", resourceData.Id())
+			resourceData.SetId("")
+			return nil
+		}
+		return err
+	}
+	return setVolcanoQueue(resourceData, queue)
+}
+
+func resourceKubeFlowVolcanoQueueUpdate(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutUpdate)
+	defer cancel()
+
+	ops := kubernetes.AppendPatchOps("metadata.0.", "/metadata/", resourceData, make([]patch.PatchOperation, 0))
+	if resourceData.HasChange("spec.0.weight") {
+		ops = append(ops, &patch.AddOperation{Path: "/spec/weight", Value: resourceData.Get("spec.0.weight").(int)})
+	}
+	if resourceData.HasChange("spec.0.reclaimable") {
+		ops = append(ops, &patch.AddOperation{Path: "/spec/reclaimable", Value: resourceData.Get("spec.0.reclaimable").(bool)})
+	}
+	if resourceData.HasChange("spec.0.capability") {
+		capability := resourceData.Get("spec.0.capability").(map[string]interface{})
+		if len(capability) == 0 {
+			ops = append(ops, &patch.RemoveOperation{Path: "/spec/capability"})
+		} else {
+			ops = append(ops, &patch.AddOperation{Path: "/spec/capability", Value: capability})
+		}
+	}
+	if len(ops) == 0 {
+		return resourceKubeFlowVolcanoQueueRead(resourceData, meta)
+	}
+
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating Volcano queue %s: %s", resourceData.Id(), data)
+	out, err := cli.UpdateVolcanoQueue(ctx, resourceData.Id(), data)
+	if err != nil {
+		return err
+	}
+	return setVolcanoQueue(resourceData, out)
+}
+
+func resourceKubeFlowVolcanoQueueDelete(resourceData *schema.ResourceData, meta interface{}) error {
+	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
+
+	log.Printf("[INFO] Deleting Volcano queue %s", resourceData.Id())
+	if err := cli.DeleteVolcanoQueue(ctx, resourceData.Id()); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	resourceData.SetId("")
+	return nil
+}
+
+func expandVolcanoQueue(resourceData *schema.ResourceData) (*unstructured.Unstructured, error) {
+	metadata := kubernetes.ExpandMetadata(resourceData.Get("metadata").([]interface{}))
+
+	spec := map[string]interface{}{
+		"weight":      int64(resourceData.Get("spec.0.weight").(int)),
+		"reclaimable": resourceData.Get("spec.0.reclaimable").(bool),
+	}
+	if v := resourceData.Get("spec.0.capability").(map[string]interface{}); len(v) > 0 {
+		capability, err := utils.ExpandMapToResourceList(v)
+		if err != nil {
+			return nil, err
+		}
+		out := map[string]interface{}{}
+		for name, quantity := range *capability {
+			out[string(name)] = quantity.String()
+		}
+		spec["capability"] = out
+	}
+
+	queue := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	queue.SetName(metadata.Name)
+	queue.SetLabels(metadata.Labels)
+	queue.SetAnnotations(metadata.Annotations)
+	return queue, nil
+}
+
+func setVolcanoQueue(resourceData *schema.ResourceData, queue *unstructured.Unstructured) error {
+	metadata := metav1.ObjectMeta{
+		Name:            queue.GetName(),
+		Labels:          queue.GetLabels(),
+		Annotations:     queue.GetAnnotations(),
+		ResourceVersion: queue.GetResourceVersion(),
+		SelfLink:        queue.GetSelfLink(),
+		UID:             queue.GetUID(),
+		Generation:      queue.GetGeneration(),
+	}
+	if err := resourceData.Set("metadata", kubernetes.FlattenMetadata(metadata)); err != nil {
+		return err
+	}
+
+	spec := map[string]interface{}{}
+	if v, ok, _ := unstructured.NestedInt64(queue.Object, "spec", "weight"); ok {
+		spec["weight"] = int(v)
+	}
+	// Volcano treats an unset reclaimable as true.
+	spec["reclaimable"] = true
+	if v, ok, _ := unstructured.NestedBool(queue.Object, "spec", "reclaimable"); ok {
+		spec["reclaimable"] = v
+	}
+	if v, ok, _ := unstructured.NestedStringMap(queue.Object, "spec", "capability"); ok {
+		spec["capability"] = v
+	}
+	if err := resourceData.Set("spec", []interface{}{spec}); err != nil {
+		return err
+	}
+
+	state, _, _ := unstructured.NestedString(queue.Object, "status", "state")
+	return resourceData.Set("state", state)
+}
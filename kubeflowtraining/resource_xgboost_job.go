@@ -1,20 +1,21 @@
 package kubeflowtraining
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/client"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/xgboost_job"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 func resourceKubeFlowXGBoostJob() *schema.Resource {
@@ -37,106 +38,85 @@ func resourceKubeFlowXGBoostJob() *schema.Resource {
 
 func resourceKubeFlowXGBoostJobCreate(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutCreate)
+	defer cancel()
 
 	xgbj, err := xgboost_job.FromResourceData(resourceData)
 	if err != nil {
 		return err
 	}
 
-	log.Printf("[INFO] Creating new XGBoostJob: %#v", xgbj)
-	if err := cli.CreateXGBoostJob(xgbj); err != nil {
-		return err
+	log.Printf("[INFO] Creating new XGBoostJob: %s", utils.Redacted(xgbj))
+	if err := cli.CreateXGBoostJob(ctx, xgbj); err != nil {
+		existing, err := adoptExistingJob(ctx, cli, resourceData, "XGBoostJob", xgbj.Namespace, xgbj.Name, xgbj, err)
+		if err != nil {
+			return err
+		}
+		xgbj = existing.(*kubeflowv1.XGBoostJob)
 	}
-	log.Printf("[INFO] Submitted new XGBoostJob: %#v", xgbj)
+	log.Printf("[INFO] Submitted new XGBoostJob: %s", utils.Redacted(xgbj))
 	if err := xgboost_job.ToResourceData(*xgbj, resourceData); err != nil {
 		return err
 	}
 	resourceData.SetId(utils.BuildId(xgbj.ObjectMeta))
+	if !resourceData.Get("wait_for_completion").(bool) {
+		log.Printf("[INFO] Not waiting for XGBoostJob %s to complete, wait_for_completion is false", xgbj.Name)
+		return nil
+	}
 
 	// Wait for XGBoostJob instance's status phase to be succeeded:
 	name := xgbj.ObjectMeta.Name
 	namespace := xgbj.ObjectMeta.Namespace
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Creating"},
+	progress := newJobProgress(cli, "XGBoostJob", namespace, name)
+	stateConf := &jobStateConf{
+		Pending: jobPendingStates,
 		Target:  []string{"Succeeded"},
 		Timeout: resourceData.Timeout(schema.TimeoutCreate),
 		Refresh: func() (interface{}, string, error) {
-			var err error
-			xgbj, err = cli.GetXGBoostJob(namespace, name)
+			xgbj, err := cli.GetXGBoostJob(ctx, namespace, name)
 			if err != nil {
 				if errors.IsNotFound(err) {
 					log.Printf("[DEBUG] XGBoostJob %s is not created yet", name)
-					return xgbj, "Creating", nil
+					return nil, "Creating", nil
 				}
-				return xgbj, "", err
+				return nil, "", err
 			}
-
-			for _, c := range xgbj.Status.Conditions {
-				if c.Type == commonv1.JobSucceeded && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] XGBoostJob %s is succeeded", name)
-					return xgbj, "Succeeded", nil
-				}
-
-				if c.Type == commonv1.JobFailed && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] XGBoostJob %s is failed", name)
-					return xgbj, "Failed", nil
-				}
-
-				if c.Type == commonv1.JobRunning && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] XGBoostJob %s is running", name)
-					return xgbj, "Running", nil
-				}
-
-				if c.Type == commonv1.JobRunning && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] XGBoostJob %s is pending", name)
-					return xgbj, "Pending", nil
-				}
-
-				if c.Type == commonv1.JobCreated && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] XGBoostJob %s is created", name)
-					return xgbj, "Created", nil
-				}
-
-				if c.Type == commonv1.JobCreated && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] XGBoostJob %s is creating", name)
-					return xgbj, "Creating", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionTrue {
-					log.Printf("[DEBUG] XGBoostJob %s is restarting", name)
-					return xgbj, "Restarting", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionFalse {
-					log.Printf("[DEBUG] XGBoostJob %s is restarting", name)
-					return xgbj, "Restarting", nil
-				}
-
-				if c.Type == commonv1.JobRestarting && c.Status == corev1.ConditionUnknown {
-					log.Printf("[DEBUG] XGBoostJob %s is restarting", name)
-					return xgbj, "Restarting", nil
-				}
-			}
-
-			if xgbj.Status.StartTime == nil {
-				log.Printf("[DEBUG] XGBoostJob %s is not started yet", name)
-				return xgbj, "Creating", nil
+			return xgbj, xgbjState(xgbj), nil
+		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return cli.WatchXGBoostJob(ctx, namespace, name, resourceVersion)
+		},
+		State: func(obj interface{}) (string, error) {
+			return xgbjState(obj.(*kubeflowv1.XGBoostJob)), nil
+		},
+		Progress: func(ctx context.Context, obj interface{}, state string) {
+			var replicaStatuses map[commonv1.ReplicaType]*commonv1.ReplicaStatus
+			if xgbj, ok := obj.(*kubeflowv1.XGBoostJob); ok {
+				replicaStatuses = xgbj.Status.ReplicaStatuses
 			}
-
-			log.Printf("[DEBUG] XGBoostJob %s is being created", name)
-			return xgbj, "Creating", nil
+			progress.log(ctx, state, replicaStatuses)
 		},
+		Describe: progress.describe,
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	obj, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
 		return fmt.Errorf("%s", err)
 	}
-	return xgboost_job.ToResourceData(*xgbj, resourceData)
+	xgbj = obj.(*kubeflowv1.XGBoostJob)
+
+	if err := xgboost_job.ToResourceData(*xgbj, resourceData); err != nil {
+		return err
+	}
+
+	return setJobOutputs(ctx, cli, resourceData, "XGBoostJob", namespace, name)
 }
 
 func resourceKubeFlowXGBoostJobRead(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -145,18 +125,20 @@ func resourceKubeFlowXGBoostJobRead(resourceData *schema.ResourceData, meta inte
 
 	log.Printf("[INFO] Reading XGBoostJob %s", name)
 
-	xgbj, err := cli.GetXGBoostJob(namespace, name)
+	xgbj, err := cli.GetXGBoostJob(ctx, namespace, name)
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
-	log.Printf("[INFO] Received XGBoostJob: %#v", xgbj)
+	log.Printf("[INFO] Received XGBoostJob: %s", utils.Redacted(xgbj))
 
 	return xgboost_job.ToResourceData(*xgbj, resourceData)
 }
 
 func resourceKubeFlowXGBoostJobUpdate(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutUpdate)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -164,57 +146,86 @@ func resourceKubeFlowXGBoostJobUpdate(resourceData *schema.ResourceData, meta in
 	}
 
 	ops := xgboost_job.AppendPatchOps("", "", resourceData, []patch.PatchOperation{})
-	data, err := ops.MarshalJSON()
-	if err != nil {
-		return fmt.Errorf("Failed to marshal update operations: %s", err)
-	}
-
 	log.Printf("[INFO] Updating XGBoostJob: %s", ops)
 	out := &kubeflowv1.XGBoostJob{}
-	if err := cli.UpdateXGBoostJob(namespace, name, out, data); err != nil {
+	err = updateJob(ctx, cli, resourceData, "XGBoostJob", namespace, name, ops, func(data []byte) error {
+		return cli.UpdateXGBoostJob(ctx, namespace, name, out, data)
+	})
+	if err != nil {
 		return err
 	}
 
-	log.Printf("[INFO] Submitted updated XGBoostJob: %#v", out)
+	log.Printf("[INFO] Submitted updated XGBoostJob: %s", utils.Redacted(out))
 
 	return resourceKubeFlowXGBoostJobRead(resourceData, meta)
 }
 
 func resourceKubeFlowXGBoostJobDelete(resourceData *schema.ResourceData, meta interface{}) error {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutDelete)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
 		return err
 	}
 
+	err = checkJobDestroy(resourceData, "XGBoostJob", name, func() (string, error) {
+		xgbj, err := cli.GetXGBoostJob(ctx, namespace, name)
+		if err != nil {
+			return "", err
+		}
+		return xgbjState(xgbj), nil
+	})
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[INFO] Deleting XGBoostJob: %#v", name)
-	if err := cli.DeleteXGBoostJob(namespace, name); err != nil {
+	deleteOptions, waitForPods, err := kubernetes.ExpandDeleteOptions(resourceData.Get("delete_options").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err := cli.DeleteXGBoostJob(ctx, namespace, name, deleteOptions); err != nil {
 		return err
 	}
 
 	// Wait for XGBoostJob instance to be removed:
-	stateConf := &resource.StateChangeConf{
+	stateConf := &jobStateConf{
 		Pending: []string{"Deleting"},
 		Timeout: resourceData.Timeout(schema.TimeoutDelete),
 		Refresh: func() (interface{}, string, error) {
-			xgbj, err := cli.GetXGBoostJob(namespace, name)
+			xgbj, err := cli.GetXGBoostJob(ctx, namespace, name)
 			if err != nil {
 				if errors.IsNotFound(err) {
 					return nil, "", nil
 				}
-				return xgbj, "", err
+				return nil, "", err
 			}
 
 			log.Printf("[DEBUG] XGBoostJob %s is being deleted", xgbj.GetName())
 			return xgbj, "Deleting", nil
 		},
+		Watch: func(resourceVersion string) (watch.Interface, error) {
+			return cli.WatchXGBoostJob(ctx, namespace, name, resourceVersion)
+		},
+		State: func(obj interface{}) (string, error) {
+			log.Printf("[DEBUG] XGBoostJob %s is being deleted", name)
+			return "Deleting", nil
+		},
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
 		return fmt.Errorf("%s", err)
 	}
 
+	if waitForPods {
+		log.Printf("[INFO] Waiting for pods of XGBoostJob %s to be deleted", name)
+		if err := waitForJobPodsDeleted(ctx, cli, namespace, name, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("%s", err)
+		}
+	}
+
 	log.Printf("[INFO] XGBoostJob %s deleted", name)
 
 	resourceData.SetId("")
@@ -223,6 +234,8 @@ func resourceKubeFlowXGBoostJobDelete(resourceData *schema.ResourceData, meta in
 
 func resourceKubeFlowXGBoostJobExists(resourceData *schema.ResourceData, meta interface{}) (bool, error) {
 	cli := (meta).(client.Client)
+	ctx, cancel := resourceContext(resourceData, meta, schema.TimeoutRead)
+	defer cancel()
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
@@ -230,7 +243,7 @@ func resourceKubeFlowXGBoostJobExists(resourceData *schema.ResourceData, meta in
 	}
 
 	log.Printf("[INFO] Checking XGBoostJob %s", name)
-	if _, err := cli.GetXGBoostJob(namespace, name); err != nil {
+	if _, err := cli.GetXGBoostJob(ctx, namespace, name); err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
 			return false, nil
 		}
@@ -239,3 +252,10 @@ func resourceKubeFlowXGBoostJobExists(resourceData *schema.ResourceData, meta in
 	}
 	return true, nil
 }
+
+// xgbjState reports the state of a XGBoostJob from its status conditions.
+func xgbjState(xgbj *kubeflowv1.XGBoostJob) string {
+	state := jobConditionsState(xgbj.Status.Conditions)
+	log.Printf("[DEBUG] XGBoostJob %s is in state %s", xgbj.Name, state)
+	return state
+}
@@ -0,0 +1,310 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// AcceleratorAnnotation records on a pod template the accelerator block it
+// was expanded from, so that reading the job back can take out what the
+// block added.
+const AcceleratorAnnotation = "terraform.kubeflow.org/accelerator"
+
+const (
+	rdmaResource      = corev1.ResourceName("rdma/hca")
+	shmVolumeName     = "dshm"
+	shmMountPath      = "/dev/shm"
+	ipcLockCapability = corev1.Capability("IPC_LOCK")
+)
+
+// acceleratorType is what nodes with an accelerator advertise: the extended
+// resource of the device plugin, which nodes also taint with, and the label
+// node feature discovery sets.
+type acceleratorType struct {
+	resource  corev1.ResourceName
+	nodeLabel string
+}
+
+var acceleratorTypes = map[string]acceleratorType{
+	"nvidia": {resource: "nvidia.com/gpu", nodeLabel: "nvidia.com/gpu.present"},
+	"amd":    {resource: "amd.com/gpu", nodeLabel: "feature.node.kubernetes.io/amd-gpu"},
+}
+
+// accelerator is the accelerator block, as recorded in AcceleratorAnnotation.
+type accelerator struct {
+	Type    string `json:"type"`
+	Count   int    `json:"count"`
+	ShmSize string `json:"shmSize,omitempty"`
+	RDMA    bool   `json:"rdma,omitempty"`
+}
+
+func AcceleratorSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Shorthand for running the replicas on accelerators: sets the device limits on the first container, tolerates the taint of the device and selects the nodes that have it, and mounts a memory volume at /dev/shm in every container.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Description:  "Type of the accelerator: nvidia or amd.",
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"nvidia", "amd"}, false),
+				},
+				"count": {
+					Type:         schema.TypeInt,
+					Description:  "Number of accelerators of each replica.",
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"shm_size": ResourceQuantitySchema("Size limit of the memory volume at /dev/shm. Unlimited when not set.", false),
+				"rdma": {
+					Type:        schema.TypeBool,
+					Description: "Also request an RDMA device (rdma/hca) and add the IPC_LOCK capability to the first container.",
+					Optional:    true,
+					Default:     false,
+				},
+			},
+		},
+	}
+}
+
+// ExpandAccelerator adds what the accelerator block l stands for to
+// template. It fails when the template already sets any of it, which would
+// otherwise be taken out when reading the job back.
+func ExpandAccelerator(l []interface{}, template *corev1.PodTemplateSpec) error {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	in := l[0].(map[string]interface{})
+	a := accelerator{
+		Type:    in["type"].(string),
+		Count:   in["count"].(int),
+		ShmSize: in["shm_size"].(string),
+		RDMA:    in["rdma"].(bool),
+	}
+	spec := &template.Spec
+	if len(spec.Containers) == 0 {
+		return fmt.Errorf("accelerator: the pod template has no container")
+	}
+	c := &spec.Containers[0]
+
+	limits, err := a.limits()
+	if err != nil {
+		return err
+	}
+	if c.Resources.Limits == nil {
+		c.Resources.Limits = corev1.ResourceList{}
+	}
+	for _, r := range []corev1.ResourceName{a.device().resource, rdmaResource} {
+		q, ok := limits[r]
+		if !ok {
+			continue
+		}
+		if _, ok := c.Resources.Limits[r]; ok {
+			return fmt.Errorf("accelerator: container %s already sets a %s limit", c.Name, r)
+		}
+		c.Resources.Limits[r] = q
+	}
+
+	toleration := a.toleration()
+	for _, t := range spec.Tolerations {
+		if t == toleration {
+			return fmt.Errorf("accelerator: the pod template already tolerates %s", toleration.Key)
+		}
+	}
+	spec.Tolerations = append(spec.Tolerations, toleration)
+
+	key, value := a.nodeSelector()
+	if _, ok := spec.NodeSelector[key]; ok {
+		return fmt.Errorf("accelerator: the pod template already selects nodes by %s", key)
+	}
+	if spec.NodeSelector == nil {
+		spec.NodeSelector = map[string]string{}
+	}
+	spec.NodeSelector[key] = value
+
+	for _, v := range spec.Volumes {
+		if v.Name == shmVolumeName {
+			return fmt.Errorf("accelerator: the pod template already has a volume %s", shmVolumeName)
+		}
+	}
+	spec.Volumes = append(spec.Volumes, a.shmVolume())
+	for i := range spec.Containers {
+		for _, m := range spec.Containers[i].VolumeMounts {
+			if m.MountPath == shmMountPath {
+				return fmt.Errorf("accelerator: container %s already mounts %s", spec.Containers[i].Name, shmMountPath)
+			}
+		}
+		spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, corev1.VolumeMount{Name: shmVolumeName, MountPath: shmMountPath})
+	}
+
+	if a.RDMA {
+		if c.SecurityContext == nil {
+			c.SecurityContext = &corev1.SecurityContext{}
+		}
+		if c.SecurityContext.Capabilities == nil {
+			c.SecurityContext.Capabilities = &corev1.Capabilities{}
+		}
+		for _, capability := range c.SecurityContext.Capabilities.Add {
+			if capability == ipcLockCapability {
+				return fmt.Errorf("accelerator: container %s already adds the %s capability", c.Name, ipcLockCapability)
+			}
+		}
+		c.SecurityContext.Capabilities.Add = append(c.SecurityContext.Capabilities.Add, ipcLockCapability)
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[AcceleratorAnnotation] = string(data)
+	return nil
+}
+
+// FlattenAccelerator returns the accelerator block recorded on template and
+// takes what it added out of template, so that it does not show up in the
+// pod spec as well.
+func FlattenAccelerator(template *corev1.PodTemplateSpec) []interface{} {
+	var a accelerator
+	if err := json.Unmarshal([]byte(template.Annotations[AcceleratorAnnotation]), &a); err != nil {
+		return []interface{}{}
+	}
+	delete(template.Annotations, AcceleratorAnnotation)
+	if len(template.Annotations) == 0 {
+		template.Annotations = nil
+	}
+
+	spec := &template.Spec
+	if limits, err := a.limits(); err == nil && len(spec.Containers) > 0 {
+		c := &spec.Containers[0]
+		for r, q := range limits {
+			if current, ok := c.Resources.Limits[r]; ok && current.Cmp(q) == 0 {
+				delete(c.Resources.Limits, r)
+			}
+		}
+		if len(c.Resources.Limits) == 0 {
+			c.Resources.Limits = nil
+		}
+		if a.RDMA && c.SecurityContext != nil && c.SecurityContext.Capabilities != nil {
+			capabilities := c.SecurityContext.Capabilities
+			capabilities.Add = removeCapability(capabilities.Add, ipcLockCapability)
+			if len(capabilities.Add) == 0 && len(capabilities.Drop) == 0 {
+				c.SecurityContext.Capabilities = nil
+			}
+			if reflect.DeepEqual(*c.SecurityContext, corev1.SecurityContext{}) {
+				c.SecurityContext = nil
+			}
+		}
+	}
+
+	toleration := a.toleration()
+	tolerations := spec.Tolerations[:0]
+	for _, t := range spec.Tolerations {
+		if t != toleration {
+			tolerations = append(tolerations, t)
+		}
+	}
+	spec.Tolerations = tolerations
+	if len(spec.Tolerations) == 0 {
+		spec.Tolerations = nil
+	}
+
+	key, value := a.nodeSelector()
+	if spec.NodeSelector[key] == value {
+		delete(spec.NodeSelector, key)
+	}
+	if len(spec.NodeSelector) == 0 {
+		spec.NodeSelector = nil
+	}
+
+	volumes := spec.Volumes[:0]
+	for _, v := range spec.Volumes {
+		if v.Name != shmVolumeName {
+			volumes = append(volumes, v)
+		}
+	}
+	spec.Volumes = volumes
+	if len(spec.Volumes) == 0 {
+		spec.Volumes = nil
+	}
+	for i := range spec.Containers {
+		mounts := spec.Containers[i].VolumeMounts[:0]
+		for _, m := range spec.Containers[i].VolumeMounts {
+			if m.Name != shmVolumeName {
+				mounts = append(mounts, m)
+			}
+		}
+		spec.Containers[i].VolumeMounts = mounts
+		if len(mounts) == 0 {
+			spec.Containers[i].VolumeMounts = nil
+		}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"type":     a.Type,
+		"count":    a.Count,
+		"shm_size": a.ShmSize,
+		"rdma":     a.RDMA,
+	}}
+}
+
+func (a accelerator) device() acceleratorType {
+	return acceleratorTypes[a.Type]
+}
+
+func (a accelerator) limits() (corev1.ResourceList, error) {
+	device := a.device()
+	if device.resource == "" {
+		return nil, fmt.Errorf("accelerator: unknown type %q", a.Type)
+	}
+	limits := corev1.ResourceList{
+		device.resource: *resource.NewQuantity(int64(a.Count), resource.DecimalSI),
+	}
+	if a.RDMA {
+		limits[rdmaResource] = *resource.NewQuantity(1, resource.DecimalSI)
+	}
+	return limits, nil
+}
+
+func (a accelerator) toleration() corev1.Toleration {
+	return corev1.Toleration{
+		Key:      string(a.device().resource),
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+}
+
+func (a accelerator) nodeSelector() (string, string) {
+	return a.device().nodeLabel, "true"
+}
+
+func (a accelerator) shmVolume() corev1.Volume {
+	emptyDir := &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}
+	if a.ShmSize != "" {
+		if q, err := resource.ParseQuantity(a.ShmSize); err == nil {
+			emptyDir.SizeLimit = &q
+		}
+	}
+	return corev1.Volume{Name: shmVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: emptyDir}}
+}
+
+func removeCapability(capabilities []corev1.Capability, c corev1.Capability) []corev1.Capability {
+	out := capabilities[:0]
+	for _, capability := range capabilities {
+		if capability != c {
+			out = append(out, capability)
+		}
+	}
+	return out
+}
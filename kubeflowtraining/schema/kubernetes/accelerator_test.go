@@ -0,0 +1,94 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAcceleratorRoundTrip(t *testing.T) {
+	config := []interface{}{map[string]interface{}{
+		"spec": []interface{}{map[string]interface{}{
+			"container": []interface{}{
+				map[string]interface{}{
+					"name":  "pytorch",
+					"image": "pytorch:latest",
+					"volume_mount": []interface{}{map[string]interface{}{
+						"name":       "data",
+						"mount_path": "/mnt/pfs",
+					}},
+				},
+				map[string]interface{}{"name": "sidecar", "image": "busybox"},
+			},
+			"volume": []interface{}{map[string]interface{}{
+				"name": "data",
+				"empty_dir": []interface{}{map[string]interface{}{
+					"medium": "",
+				}},
+			}},
+		}},
+	}}
+	plain, err := ExpandPodTemplate(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	template, err := ExpandPodTemplate(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	block := []interface{}{map[string]interface{}{
+		"type":     "nvidia",
+		"count":    8,
+		"shm_size": "64Gi",
+		"rdma":     true,
+	}}
+	if err := ExpandAccelerator(block, template); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	c := template.Spec.Containers[0]
+	if q := c.Resources.Limits["nvidia.com/gpu"]; q.String() != "8" {
+		t.Errorf("expected 8 GPUs, got %s", q.String())
+	}
+	if q := c.Resources.Limits["rdma/hca"]; q.String() != "1" {
+		t.Errorf("expected an RDMA device, got %s", q.String())
+	}
+	if c.SecurityContext == nil || !reflect.DeepEqual(c.SecurityContext.Capabilities.Add, []corev1.Capability{"IPC_LOCK"}) {
+		t.Errorf("expected IPC_LOCK to be added, got %v", c.SecurityContext)
+	}
+	if v := template.Spec.NodeSelector["nvidia.com/gpu.present"]; v != "true" {
+		t.Errorf("expected GPU nodes to be selected, got %v", template.Spec.NodeSelector)
+	}
+	if len(template.Spec.Tolerations) != 1 || template.Spec.Tolerations[0].Key != "nvidia.com/gpu" {
+		t.Errorf("expected the GPU taint to be tolerated, got %v", template.Spec.Tolerations)
+	}
+	for _, c := range template.Spec.Containers {
+		if n := len(c.VolumeMounts); n == 0 || c.VolumeMounts[n-1].MountPath != "/dev/shm" {
+			t.Errorf("expected container %s to mount /dev/shm, got %v", c.Name, c.VolumeMounts)
+		}
+	}
+	if v := template.Spec.Volumes[1].EmptyDir; v == nil || v.Medium != corev1.StorageMediumMemory || v.SizeLimit.String() != "64Gi" {
+		t.Errorf("expected a 64Gi memory volume, got %v", template.Spec.Volumes[1])
+	}
+
+	if err := ExpandAccelerator(block, template.DeepCopy()); err == nil {
+		t.Error("expected expanding the block twice to fail")
+	}
+
+	accelerator := FlattenAccelerator(template)
+	if !reflect.DeepEqual(accelerator, []interface{}{block[0]}) {
+		t.Errorf("expected the block to be read back, got %v", accelerator)
+	}
+	expected, err := FlattenPodTemplateSpec(*plain)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	actual, err := FlattenPodTemplateSpec(*template)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected the template to be read back without what the block added:\n%v\ngot:\n%v", expected, actual)
+	}
+}
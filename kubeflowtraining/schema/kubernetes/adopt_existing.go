@@ -0,0 +1,14 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func AdoptExistingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "When a job with the same name already exists, e.g. after an interrupted apply, adopt it into state instead of failing, provided it matches the configuration.",
+		Optional:    true,
+		Default:     false,
+	}
+}
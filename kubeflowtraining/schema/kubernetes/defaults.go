@@ -0,0 +1,47 @@
+package kubernetes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PruneDefaultedPodSpec removes from live what the operator defaulting added
+// to the pod spec, so that reading a job back does not show a diff against a
+// config that never set it. prior is the pod spec as configured, defaulted is
+// prior after the operator defaulting ran on it.
+func PruneDefaultedPodSpec(prior *corev1.PodSpec, defaulted *corev1.PodSpec, live *corev1.PodSpec) {
+	for i := range live.Containers {
+		c := &live.Containers[i]
+		p := findContainer(prior.Containers, c.Name)
+		d := findContainer(defaulted.Containers, c.Name)
+		if p == nil || d == nil {
+			continue
+		}
+
+		ports := make([]corev1.ContainerPort, 0, len(c.Ports))
+		for _, port := range c.Ports {
+			if !hasContainerPort(p.Ports, port) && hasContainerPort(d.Ports, port) {
+				continue
+			}
+			ports = append(ports, port)
+		}
+		c.Ports = ports
+	}
+}
+
+func findContainer(containers []corev1.Container, name string) *corev1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	return nil
+}
+
+func hasContainerPort(ports []corev1.ContainerPort, port corev1.ContainerPort) bool {
+	for _, p := range ports {
+		if p.Name == port.Name && p.ContainerPort == port.ContainerPort {
+			return true
+		}
+	}
+	return false
+}
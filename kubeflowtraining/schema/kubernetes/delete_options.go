@@ -0,0 +1,102 @@
+package kubernetes
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func deleteOptionsFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"propagation_policy": {
+			Type:         schema.TypeString,
+			Description:  "Whether and how garbage collection will be performed on the job's pods and services. One of Orphan, Background or Foreground. Defaults to the server's policy for the job kind.",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"Orphan", "Background", "Foreground"}, false),
+		},
+		"grace_period_seconds": {
+			// Use TypeString to allow an "unspecified" value,
+			Type:         schema.TypeString,
+			Description:  "The duration in seconds before the job should be deleted. Zero means delete immediately. By default the server's default grace period is used.",
+			Optional:     true,
+			ValidateFunc: utils.ValidateTypeStringNullableInt,
+		},
+		"wait_for_pod_cleanup": {
+			Type:        schema.TypeBool,
+			Description: "Wait until all pods of the job are gone, not just the job object, so the capacity they held can be reused right away.",
+			Optional:    true,
+			Default:     false,
+		},
+	}
+}
+
+func DeleteOptionsSchema() *schema.Schema {
+	fields := deleteOptionsFields()
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Options applied when the job is deleted.",
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+
+}
+
+// ExpandDeleteOptions returns the delete options of a job and whether the
+// deletion should wait for the job's pods to be gone.
+func ExpandDeleteOptions(deleteOptions []interface{}) (metav1.DeleteOptions, bool, error) {
+	result := metav1.DeleteOptions{}
+	if len(deleteOptions) == 0 || deleteOptions[0] == nil {
+		return result, false, nil
+	}
+
+	in := deleteOptions[0].(map[string]interface{})
+
+	if v, ok := in["propagation_policy"].(string); ok && v != "" {
+		policy := metav1.DeletionPropagation(v)
+		result.PropagationPolicy = &policy
+	}
+	if v, ok := in["grace_period_seconds"].(string); ok && v != "" {
+		seconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return result, false, err
+		}
+		result.GracePeriodSeconds = &seconds
+	}
+	waitForPods, _ := in["wait_for_pod_cleanup"].(bool)
+
+	return result, waitForPods, nil
+}
+
+func DeletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "When true, destroying the job (including a replacement) fails. Set it to false and apply before destroying.",
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+func PreventDestroyWhileRunningSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "When true, destroying the job fails while the job is running, unless force_destroy is set.",
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+func ForceDestroySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Allow destroying a running job even when prevent_destroy_while_running is set.",
+		Optional:    true,
+		Default:     false,
+	}
+}
@@ -0,0 +1,106 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
+)
+
+// The labels Kueue reads on the jobs it admits.
+const (
+	KueueQueueNameLabel     = "kueue.x-k8s.io/queue-name"
+	KueuePriorityClassLabel = "kueue.x-k8s.io/priority-class"
+)
+
+func KueueSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Submit the job to Kueue, which creates it suspended and starts it once the queue admits it. Set the queue here rather than through metadata labels.",
+		Optional:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"queue_name": {
+					Type:         schema.TypeString,
+					Description:  "Name of the LocalQueue, in the namespace of the job, to submit the job to.",
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validateName,
+				},
+				"priority_class": {
+					Type:         schema.TypeString,
+					Description:  "Name of the WorkloadPriorityClass of the job.",
+					Optional:     true,
+					ForceNew:     true,
+					ValidateFunc: validateName,
+				},
+			},
+		},
+	}
+}
+
+// ExpandKueue sets the Kueue labels of the kueue block l on meta.
+func ExpandKueue(l []interface{}, meta *metav1.ObjectMeta) {
+	if len(l) == 0 || l[0] == nil {
+		return
+	}
+	in := l[0].(map[string]interface{})
+
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+	if v, ok := in["queue_name"].(string); ok && v != "" {
+		meta.Labels[KueueQueueNameLabel] = v
+	}
+	if v, ok := in["priority_class"].(string); ok && v != "" {
+		meta.Labels[KueuePriorityClassLabel] = v
+	}
+}
+
+// FlattenKueue returns the kueue block of the Kueue labels of meta and takes
+// them out of its labels, so that they do not show up in metadata as well.
+// prior and priorLabels are the kueue block and the metadata labels in config
+// or state. Jobs labelled through metadata, whose prior labels hold the queue
+// name while prior is unset, keep the labels there, as moving them would
+// replace the jobs. Imported jobs, with neither, get the kueue block.
+func FlattenKueue(meta *metav1.ObjectMeta, prior []interface{}, priorLabels map[string]interface{}) []interface{} {
+	if _, ok := priorLabels[KueueQueueNameLabel]; ok && len(prior) == 0 {
+		return []interface{}{}
+	}
+	queueName, ok := meta.Labels[KueueQueueNameLabel]
+	if !ok {
+		return []interface{}{}
+	}
+	att := map[string]interface{}{
+		"queue_name": queueName,
+	}
+	if v, ok := meta.Labels[KueuePriorityClassLabel]; ok {
+		att["priority_class"] = v
+	}
+
+	labels := make(map[string]string, len(meta.Labels))
+	for k, v := range meta.Labels {
+		if k != KueueQueueNameLabel && k != KueuePriorityClassLabel {
+			labels[k] = v
+		}
+	}
+	if len(labels) == 0 {
+		labels = nil
+	}
+	meta.Labels = labels
+
+	return []interface{}{att}
+}
+
+// AppendJobPatchOps is AppendPatchOps for the metadata of a job with the kueue
+// block kueue. FlattenKueue keeps the Kueue labels out of the labels in state,
+// so they are merged back into the labels diff: a job whose only labels are
+// the Kueue ones would otherwise have its labels replaced as a whole, which
+// drops them.
+func AppendJobPatchOps(keyPrefix, pathPrefix string, kueue []interface{}, resourceData *schema.ResourceData, ops []patch.PatchOperation) patch.PatchOperations {
+	meta := metav1.ObjectMeta{}
+	ExpandKueue(kueue, &meta)
+	return appendMetadataPatchOps(keyPrefix, pathPrefix, resourceData, ops, meta.Labels)
+}
@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFlattenKueue(t *testing.T) {
+	labels := map[string]string{
+		"team":                  "ml",
+		KueueQueueNameLabel:     "research",
+		KueuePriorityClassLabel: "high",
+	}
+	block := []interface{}{map[string]interface{}{
+		"queue_name":     "research",
+		"priority_class": "high",
+	}}
+
+	cases := map[string]struct {
+		prior          []interface{}
+		priorLabels    map[string]interface{}
+		expected       []interface{}
+		expectedLabels map[string]string
+	}{
+		"labelled through metadata": {
+			priorLabels:    map[string]interface{}{"team": "ml", KueueQueueNameLabel: "research", KueuePriorityClassLabel: "high"},
+			expected:       []interface{}{},
+			expectedLabels: labels,
+		},
+		"kueue block": {
+			prior:          block,
+			priorLabels:    map[string]interface{}{"team": "ml"},
+			expected:       block,
+			expectedLabels: map[string]string{"team": "ml"},
+		},
+		"imported": {
+			expected:       block,
+			expectedLabels: map[string]string{"team": "ml"},
+		},
+	}
+	for name, tc := range cases {
+		meta := metav1.ObjectMeta{Labels: map[string]string{}}
+		for k, v := range labels {
+			meta.Labels[k] = v
+		}
+		if actual := FlattenKueue(&meta, tc.prior, tc.priorLabels); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected %#v, got %#v", name, tc.expected, actual)
+		}
+		if !reflect.DeepEqual(meta.Labels, tc.expectedLabels) {
+			t.Errorf("%s: expected labels %v, got %v", name, tc.expectedLabels, meta.Labels)
+		}
+	}
+}
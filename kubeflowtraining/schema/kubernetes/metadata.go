@@ -86,6 +86,11 @@ func metadataSchema(objectName string, generatableName bool) *schema.Schema {
 
 }
 
+// MetadataSchema is the metadata of a cluster scoped object.
+func MetadataSchema(objectName string, generatableName bool) *schema.Schema {
+	return metadataSchema(objectName, generatableName)
+}
+
 func NamespacedMetadataSchema(objectName string, generatableName bool) *schema.Schema {
 	return namespacedMetadataSchemaIsTemplate(objectName, generatableName, false)
 }
@@ -180,6 +185,13 @@ func FlattenMetadata(meta metav1.ObjectMeta) []interface{} {
 }
 
 func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation) patch.PatchOperations {
+	return appendMetadataPatchOps(keyPrefix, pathPrefix, resourceData, ops, nil)
+}
+
+// appendMetadataPatchOps appends the operations patching the annotations and
+// labels of the metadata at keyPrefix. extraLabels are labels set outside of
+// metadata, which are merged into both sides of the labels diff.
+func appendMetadataPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation, extraLabels map[string]string) patch.PatchOperations {
 	if resourceData.HasChange(keyPrefix + "annotations") {
 		oldV, newV := resourceData.GetChange(keyPrefix + "annotations")
 		diffOps := patch.DiffStringMap(pathPrefix+"annotations", oldV.(map[string]interface{}), newV.(map[string]interface{}))
@@ -187,12 +199,26 @@ func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceD
 	}
 	if resourceData.HasChange(keyPrefix + "labels") {
 		oldV, newV := resourceData.GetChange(keyPrefix + "labels")
-		diffOps := patch.DiffStringMap(pathPrefix+"labels", oldV.(map[string]interface{}), newV.(map[string]interface{}))
+		diffOps := patch.DiffStringMap(pathPrefix+"labels", mergeLabels(oldV.(map[string]interface{}), extraLabels), mergeLabels(newV.(map[string]interface{}), extraLabels))
 		ops = append(ops, diffOps...)
 	}
 	return ops
 }
 
+func mergeLabels(labels map[string]interface{}, extraLabels map[string]string) map[string]interface{} {
+	if len(extraLabels) == 0 {
+		return labels
+	}
+	merged := make(map[string]interface{}, len(labels)+len(extraLabels))
+	for k, v := range labels {
+		merged[k] = v
+	}
+	for k, v := range extraLabels {
+		merged[k] = v
+	}
+	return merged
+}
+
 func removeInternalKeys(m map[string]string, d map[string]interface{}) map[string]string {
 	for k := range m {
 		if isInternalKey(k) && !isKeyInMap(k, d) {
@@ -0,0 +1,24 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func OutputsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "Key/values the job reported as a JSON object in the termination message (/dev/termination-log) of its outputs replica, read once the job succeeded.",
+		Computed:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+func OutputsReplicaTypeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Replica type whose first pod reports the outputs. Defaults to the master, chief or launcher of the job, or its first worker when it has none.",
+		Optional:    true,
+	}
+}
@@ -0,0 +1,14 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func QuotaPreflightSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Before submitting the job, check that its pods fit in the ResourceQuotas and LimitRanges of the namespace, and fail with a per-resource breakdown when they do not instead of leaving them pending.",
+		Optional:    true,
+		Default:     false,
+	}
+}
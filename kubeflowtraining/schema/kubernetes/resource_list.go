@@ -0,0 +1,31 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourceListSchema is an optional map of resource names to quantities, e.g.
+// the minimum resources of a gang or the capability of a queue.
+func ResourceListSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeMap,
+		Description:      description,
+		Optional:         true,
+		Elem:             &schema.Schema{Type: schema.TypeString},
+		ValidateFunc:     validateResourceList,
+		DiffSuppressFunc: suppressEquivalentResourceQuantity,
+	}
+}
+
+// ResourceQuantitySchema is a quantity of a resource, e.g. the quota of a
+// flavor of a Kueue cluster queue.
+func ResourceQuantitySchema(description string, required bool) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Description:      description,
+		Required:         required,
+		Optional:         !required,
+		ValidateFunc:     validateResourceQuantity,
+		DiffSuppressFunc: suppressEquivalentResourceQuantity,
+	}
+}
@@ -262,6 +262,13 @@ func containerFields(isUpdatable, isInitContainer bool) map[string]*schema.Schem
 						Optional:    true,
 						Description: `Variable references $(VAR_NAME) are expanded using the previous defined environment variables in the container and any service environment variables. If a variable cannot be resolved, the reference in the input string will be unchanged. The $(VAR_NAME) syntax can be escaped with a double $$, ie: $$(VAR_NAME). Escaped references will never be expanded, regardless of whether the variable exists or not. Defaults to "".`,
 					},
+					"sensitive_value": {
+						Type:        schema.TypeString,
+						ForceNew:    !isUpdatable,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Value of the environment variable, kept out of plans and logs. Use instead of value for secrets.",
+					},
 					"value_from": {
 						Type:        schema.TypeList,
 						Optional:    true,
@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -37,6 +39,12 @@ func ExpandPodTemplate(l []interface{}) (*corev1.PodTemplateSpec, error) {
 			return obj, err
 		}
 		obj.Spec = *podSpec
+
+		if spec, ok := v[0].(map[string]interface{}); ok {
+			if names := sensitiveEnvNames(spec); len(names) > 0 {
+				obj.Annotations = map[string]string{SensitiveEnvAnnotation: strings.Join(names, ",")}
+			}
+		}
 	}
 	return obj, nil
 }
@@ -53,6 +61,7 @@ func FlattenPodTemplateSpec(t corev1.PodTemplateSpec, prefix ...string) ([]inter
 	if err != nil {
 		return []interface{}{template}, err
 	}
+	markSensitiveEnv(spec, t.Annotations[SensitiveEnvAnnotation])
 	template["spec"] = spec
 
 	return []interface{}{template}, nil
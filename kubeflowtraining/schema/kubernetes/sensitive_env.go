@@ -0,0 +1,81 @@
+package kubernetes
+
+import (
+	"sort"
+	"strings"
+)
+
+// SensitiveEnvAnnotation records on a pod template which environment
+// variables were set through sensitive_value, as container/name pairs, so
+// that reading the job back keeps their values out of plans.
+const SensitiveEnvAnnotation = "terraform.kubeflow.org/sensitive-env"
+
+// sensitiveEnvNames returns the container/name pairs of the environment
+// variables of a pod spec config that are set through sensitive_value.
+func sensitiveEnvNames(spec map[string]interface{}) []string {
+	var names []string
+	for _, key := range []string{"container", "init_container"} {
+		containers, _ := spec[key].([]interface{})
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			envs, _ := container["env"].([]interface{})
+			for _, e := range envs {
+				env, ok := e.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if v, ok := env["sensitive_value"].(string); ok && v != "" {
+					names = append(names, sensitiveEnvName(container, env))
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// markSensitiveEnv moves the values of the environment variables listed in
+// annotation from value to sensitive_value in a flattened pod spec.
+func markSensitiveEnv(spec []interface{}, annotation string) {
+	if annotation == "" || len(spec) == 0 {
+		return
+	}
+	sensitive := map[string]bool{}
+	for _, name := range strings.Split(annotation, ",") {
+		sensitive[name] = true
+	}
+
+	m, ok := spec[0].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, key := range []string{"container", "init_container"} {
+		containers, _ := m[key].([]interface{})
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			envs, _ := container["env"].([]interface{})
+			for _, e := range envs {
+				env, ok := e.(map[string]interface{})
+				if !ok || !sensitive[sensitiveEnvName(container, env)] {
+					continue
+				}
+				if v, ok := env["value"]; ok {
+					env["sensitive_value"] = v
+					delete(env, "value")
+				}
+			}
+		}
+	}
+}
+
+func sensitiveEnvName(container map[string]interface{}, env map[string]interface{}) string {
+	containerName, _ := container["name"].(string)
+	envName, _ := env["name"].(string)
+	return containerName + "/" + envName
+}
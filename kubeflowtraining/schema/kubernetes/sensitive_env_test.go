@@ -0,0 +1,42 @@
+package kubernetes
+
+import (
+	"testing"
+)
+
+func TestSensitiveEnvRoundTrip(t *testing.T) {
+	template, err := ExpandPodTemplate([]interface{}{map[string]interface{}{
+		"spec": []interface{}{map[string]interface{}{
+			"container": []interface{}{map[string]interface{}{
+				"name":  "pytorch",
+				"image": "pytorch:latest",
+				"env": []interface{}{
+					map[string]interface{}{"name": "EPOCHS", "value": "10"},
+					map[string]interface{}{"name": "API_KEY", "sensitive_value": "secret"},
+				},
+			}},
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v := template.Spec.Containers[0].Env[1].Value; v != "secret" {
+		t.Fatalf("expected the sensitive value to be set, got %q", v)
+	}
+	if v := template.Annotations[SensitiveEnvAnnotation]; v != "pytorch/API_KEY" {
+		t.Fatalf("unexpected %s annotation %q", SensitiveEnvAnnotation, v)
+	}
+
+	flattened, err := FlattenPodTemplateSpec(*template)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	spec := flattened[0].(map[string]interface{})["spec"].([]interface{})[0].(map[string]interface{})
+	env := spec["container"].([]interface{})[0].(map[string]interface{})["env"].([]interface{})
+	if v := env[0].(map[string]interface{}); v["value"] != "10" {
+		t.Fatalf("expected EPOCHS to stay in value, got %v", v)
+	}
+	if v := env[1].(map[string]interface{}); v["sensitive_value"] != "secret" || v["value"] != nil {
+		t.Fatalf("expected API_KEY to be read back into sensitive_value, got %v", v)
+	}
+}
@@ -748,6 +748,9 @@ func expandContainerEnv(in []interface{}) ([]v1.EnvVar, error) {
 		if value, ok := p["value"]; ok {
 			envs[i].Value = value.(string)
 		}
+		if value, ok := p["sensitive_value"].(string); ok && value != "" {
+			envs[i].Value = value
+		}
 		if v, ok := p["value_from"].([]interface{}); ok && len(v) > 0 {
 			var err error
 			envs[i].ValueFrom, err = expandEnvValueFrom(v)
@@ -290,3 +290,45 @@ func validateTypeStringNullableIntOrPercent(v interface{}, key string) (ws []str
 
 	return
 }
+
+// ValidateRestartPolicy validates the restart policy of a job replica.
+var ValidateRestartPolicy = validateAttributeValueIsIn([]string{"Always", "OnFailure", "Never", "ExitCode"})
+
+// ValidateCleanPodPolicy validates the policy for cleaning up the pods of a
+// finished job.
+var ValidateCleanPodPolicy = validateAttributeValueIsIn([]string{"None", "Running", "All"})
+
+// ValidateReplicaTypes validates that the keys of a replica specs map name
+// replica types of the job kind, in any case.
+func ValidateReplicaTypes(replicaTypes ...string) schema.SchemaValidateFunc {
+	return func(value interface{}, key string) (ws []string, es []error) {
+		m := value.(map[string]interface{})
+		for k := range m {
+			if _, ok := ReplicaTypeName(k, replicaTypes...); !ok {
+				es = append(es, fmt.Errorf("%s: unknown replica type %q, must be one of %s", key, k, strings.Join(replicaTypes, ", ")))
+			}
+		}
+		return
+	}
+}
+
+// ReplicaTypeName returns the replica type matching key in any case, as
+// spelled by the job kind.
+func ReplicaTypeName(key string, replicaTypes ...string) (string, bool) {
+	for _, t := range replicaTypes {
+		if strings.EqualFold(key, t) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// ValidateSingleReplica validates the replica count of a replica type the
+// operator runs exactly one of, such as a PyTorch master or an MPI launcher.
+func ValidateSingleReplica(value interface{}, key string) (ws []string, es []error) {
+	v := value.(int)
+	if v != 1 {
+		es = append(es, fmt.Errorf("%s must be 1, there can only be a single replica of this type, got %d", key, v))
+	}
+	return
+}
@@ -0,0 +1,28 @@
+package kubernetes
+
+import (
+	"testing"
+)
+
+func TestValidateReplicaTypes(t *testing.T) {
+	validate := ValidateReplicaTypes("Master", "Worker")
+
+	if _, es := validate(map[string]interface{}{"master": "", "Worker": ""}, "replica_specs"); len(es) != 0 {
+		t.Fatalf("unexpected errors: %v", es)
+	}
+	if _, es := validate(map[string]interface{}{"launcher": ""}, "replica_specs"); len(es) != 1 {
+		t.Fatalf("expected one error for an unknown replica type, got %v", es)
+	}
+}
+
+func TestReplicaTypeName(t *testing.T) {
+	if name, ok := ReplicaTypeName("master", "Master", "Worker"); !ok || name != "Master" {
+		t.Fatalf("expected Master, got %q", name)
+	}
+	if name, ok := ReplicaTypeName("WORKER", "Master", "Worker"); !ok || name != "Worker" {
+		t.Fatalf("expected Worker, got %q", name)
+	}
+	if _, ok := ReplicaTypeName("chief", "Master", "Worker"); ok {
+		t.Fatal("expected chief not to match")
+	}
+}
@@ -0,0 +1,14 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func WaitForCompletionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Wait for the job to succeed before completing the apply. When false, the job is only submitted and outputs are not captured; gate downstream resources on a kubeflow_job_wait resource for the job instead, so that they wait without the job resource blocking.",
+		Optional:    true,
+		Default:     true,
+	}
+}
@@ -1,6 +1,8 @@
 package mpi_job
 
 import (
+	"reflect"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	// mpiv2beta1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 
@@ -14,9 +16,19 @@ import (
 
 func MPIJobFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"metadata": kubernetes.NamespacedMetadataSchema("MPIJob", false),
-		"spec":     mpiJobSpecSchema(),
-		"status":   mpiJobStatusSchema(),
+		"metadata":                      kubernetes.NamespacedMetadataSchema("MPIJob", false),
+		"spec":                          mpiJobSpecSchema(),
+		"status":                        mpiJobStatusSchema(),
+		"delete_options":                kubernetes.DeleteOptionsSchema(),
+		"deletion_protection":           kubernetes.DeletionProtectionSchema(),
+		"prevent_destroy_while_running": kubernetes.PreventDestroyWhileRunningSchema(),
+		"force_destroy":                 kubernetes.ForceDestroySchema(),
+		"outputs":                       kubernetes.OutputsSchema(),
+		"outputs_replica_type":          kubernetes.OutputsReplicaTypeSchema(),
+		"adopt_existing":                kubernetes.AdoptExistingSchema(),
+		"kueue":                         kubernetes.KueueSchema(),
+		"quota_preflight":               kubernetes.QuotaPreflightSchema(),
+		"wait_for_completion":           kubernetes.WaitForCompletionSchema(),
 	}
 }
 
@@ -64,6 +76,7 @@ func FromResourceData(resourceData *schema.ResourceData) (*mpiv2beta1.MPIJob, er
 	result := &mpiv2beta1.MPIJob{}
 
 	result.ObjectMeta = kubernetes.ExpandMetadata(resourceData.Get("metadata").([]interface{}))
+	kubernetes.ExpandKueue(resourceData.Get("kueue").([]interface{}), &result.ObjectMeta)
 	spec, err := expandMPIJobSpec(resourceData.Get("spec").([]interface{}))
 	if err != nil {
 		return result, err
@@ -79,6 +92,14 @@ func FromResourceData(resourceData *schema.ResourceData) (*mpiv2beta1.MPIJob, er
 }
 
 func ToResourceData(vm mpiv2beta1.MPIJob, resourceData *schema.ResourceData) error {
+	if prior, err := FromResourceData(resourceData); err == nil {
+		vm = *vm.DeepCopy()
+		pruneDefaults(prior, &vm)
+	}
+
+	if err := resourceData.Set("kueue", kubernetes.FlattenKueue(&vm.ObjectMeta, resourceData.Get("kueue").([]interface{}), resourceData.Get("metadata.0.labels").(map[string]interface{}))); err != nil {
+		return err
+	}
 	if err := resourceData.Set("metadata", kubernetes.FlattenMetadata(vm.ObjectMeta)); err != nil {
 		return err
 	}
@@ -92,6 +113,20 @@ func ToResourceData(vm mpiv2beta1.MPIJob, resourceData *schema.ResourceData) err
 	return nil
 }
 
+// pruneDefaults unsets the fields of live that the operator defaulted because
+// prior, the job as configured, left them unset.
+func pruneDefaults(prior *mpiv2beta1.MPIJob, live *mpiv2beta1.MPIJob) {
+	defaulted := prior.DeepCopy()
+	mpiv2beta1.SetDefaults_MPIJob(defaulted)
+
+	if prior.Spec.RunPolicy.CleanPodPolicy == nil && reflect.DeepEqual(live.Spec.RunPolicy.CleanPodPolicy, defaulted.Spec.RunPolicy.CleanPodPolicy) {
+		live.Spec.RunPolicy.CleanPodPolicy = nil
+	}
+	if prior.Spec.SlotsPerWorker == nil && reflect.DeepEqual(live.Spec.SlotsPerWorker, defaulted.Spec.SlotsPerWorker) {
+		live.Spec.SlotsPerWorker = nil
+	}
+}
+
 func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation) patch.PatchOperations {
-	return kubernetes.AppendPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData, ops)
+	return kubernetes.AppendJobPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData.Get(keyPrefix+"kueue").([]interface{}), resourceData, ops)
 }
@@ -1,8 +1,6 @@
 package mpi_job
 
 import (
-	"strings"
-
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
@@ -12,17 +10,18 @@ import (
 
 func mpiJobReplicaSpecFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"launcher": mpiJobReplicaSpecSchema(),
-		"worker":   mpiJobReplicaSpecSchema(),
+		"launcher": mpiJobReplicaSpecSchema(kubernetes.ValidateSingleReplica),
+		"worker":   mpiJobReplicaSpecSchema(nil),
 	}
 }
 
-func mpiJobReplicaSpecTemplateFields() map[string]*schema.Schema {
+func mpiJobReplicaSpecTemplateFields(validateReplicas schema.SchemaValidateFunc) map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"replicas": {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  1,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validateReplicas,
 		},
 		"template": {
 			Type:     schema.TypeList,
@@ -33,20 +32,23 @@ func mpiJobReplicaSpecTemplateFields() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"restart_policy": {
-			Type:     schema.TypeString,
-			Optional: true,
-			Default:  "Never",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Never",
+			ValidateFunc: kubernetes.ValidateRestartPolicy,
 		},
+		"accelerator": kubernetes.AcceleratorSchema(),
 	}
 }
 
-func mpiJobReplicaSpecSchema() *schema.Schema {
+func mpiJobReplicaSpecSchema(validateReplicas schema.SchemaValidateFunc) *schema.Schema {
 	return &schema.Schema{
 		Type: schema.TypeList,
 		Elem: &schema.Resource{
-			Schema: mpiJobReplicaSpecTemplateFields(),
+			Schema: mpiJobReplicaSpecTemplateFields(validateReplicas),
 		},
 		Optional: true,
+		MaxItems: 1,
 	}
 }
 
@@ -57,19 +59,18 @@ func expandMPIReplicaSpec(l []interface{}) (map[mpiv2beta1.MPIReplicaType]*commo
 
 	m := make(map[mpiv2beta1.MPIReplicaType]*commonv1.ReplicaSpec)
 	for k, v := range l[0].(map[string]interface{}) {
-		if !strings.EqualFold(k, "launcher") && !strings.EqualFold(k, "worker") {
+		t, ok := kubernetes.ReplicaTypeName(k, "Launcher", "Worker")
+		if !ok {
 			continue
 		}
-		if k == "launcher" {
-			k = "Launcher"
-		} else {
-			k = "Worker"
-		}
-		replicaType := mpiv2beta1.MPIReplicaType(k)
+		replicaType := mpiv2beta1.MPIReplicaType(t)
 		replicaSpec, err := expandMPIJobReplicaSpec(v.([]interface{}))
 		if err != nil {
 			return nil, err
 		}
+		if replicaSpec == nil {
+			continue
+		}
 
 		m[replicaType] = replicaSpec
 	}
@@ -78,7 +79,7 @@ func expandMPIReplicaSpec(l []interface{}) (map[mpiv2beta1.MPIReplicaType]*commo
 
 func flattenMPIReplicaSpec(in map[mpiv2beta1.MPIReplicaType]*commonv1.ReplicaSpec) ([]interface{}, error) {
 	if in == nil {
-		return []interface{}{nil}, nil
+		return []interface{}{}, nil
 	}
 
 	m := make(map[string]interface{})
@@ -104,15 +105,18 @@ func expandMPIJobReplicaSpec(l []interface{}) (*commonv1.ReplicaSpec, error) {
 	}
 	m := l[0].(map[string]interface{})
 
-	replicas := m["replicas"].(*int32)
+	replicas := int32(m["replicas"].(int))
 	template, err := kubernetes.ExpandPodTemplate(m["template"].([]interface{}))
 	if err != nil {
 		return nil, err
 	}
+	if err := kubernetes.ExpandAccelerator(m["accelerator"].([]interface{}), template); err != nil {
+		return nil, err
+	}
 	restartPolicy := m["restart_policy"].(string)
 
 	return &commonv1.ReplicaSpec{
-		Replicas:      replicas,
+		Replicas:      &replicas,
 		Template:      *template,
 		RestartPolicy: commonv1.RestartPolicy(restartPolicy),
 	}, nil
@@ -120,19 +124,26 @@ func expandMPIJobReplicaSpec(l []interface{}) (*commonv1.ReplicaSpec, error) {
 
 func flattenMPIJobReplicaSpec(in *commonv1.ReplicaSpec) ([]interface{}, error) {
 	if in == nil {
-		return []interface{}{nil}, nil
+		return []interface{}{}, nil
 	}
 
-	replicas := in.Replicas
-	template, err := kubernetes.FlattenPodTemplateSpec(in.Template)
+	replicas := 0
+	if in.Replicas != nil {
+		replicas = int(*in.Replicas)
+	}
+	// Take what the accelerator block added out of a copy of the template.
+	podTemplate := in.Template.DeepCopy()
+	accelerator := kubernetes.FlattenAccelerator(podTemplate)
+	template, err := kubernetes.FlattenPodTemplateSpec(*podTemplate)
 	if err != nil {
 		return nil, err
 	}
-	restartPolicy := in.RestartPolicy
+	restartPolicy := string(in.RestartPolicy)
 
 	return []interface{}{map[string]interface{}{
 		"replicas":       replicas,
 		"template":       template,
 		"restart_policy": restartPolicy,
+		"accelerator":    accelerator,
 	}}, nil
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
 
 	// mpiv2beta1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 
@@ -24,9 +26,10 @@ func mpiJobSpecFields() map[string]*schema.Schema {
 			},
 		},
 		"mpi_replica_specs": {
-			Type:        schema.TypeMap,
+			Type:        schema.TypeList,
 			Description: "A map of MPIReplicaType (type) to ReplicaSpec (value). Specifies the MPI cluster configuration.",
 			Optional:    true,
+			MaxItems:    1,
 			Elem: &schema.Resource{
 				Schema: mpiJobReplicaSpecFields(),
 			},
@@ -37,9 +40,10 @@ func mpiJobSpecFields() map[string]*schema.Schema {
 			Optional:    true,
 		},
 		"clean_pod_policy": {
-			Type:        schema.TypeString,
-			Description: "CleanPodPolicy defines the policy that whether to kill pods after the job completes.",
-			Optional:    true,
+			Type:         schema.TypeString,
+			Description:  "CleanPodPolicy defines the policy that whether to kill pods after the job completes.",
+			Optional:     true,
+			ValidateFunc: kubernetes.ValidateCleanPodPolicy,
 		},
 		"slots_per_worker": {
 			Type:        schema.TypeInt,
@@ -93,8 +97,8 @@ func expandMPIJobSpec(mpiJob []interface{}) (mpiv2beta1.MPIJobSpec, error) {
 		result.MPIReplicaSpecs = mpiReplicaSpecs
 	}
 
-	if v, ok := mpiJobMap["slots_per_worker"]; ok {
-		*result.SlotsPerWorker = int32(v.(int))
+	if v, ok := mpiJobMap["slots_per_worker"].(int); ok && v != 0 {
+		result.SlotsPerWorker = utils.PtrToInt32(int32(v))
 	}
 
 	return result, nil
@@ -105,6 +109,10 @@ func flattenMPIJobSpec(in mpiv2beta1.MPIJobSpec) []interface{} {
 
 	att["run_policy"] = flattenRunPolicy(in.RunPolicy)
 
+	if in.MPIReplicaSpecs != nil {
+		att["mpi_replica_specs"], _ = flattenMPIReplicaSpec(in.MPIReplicaSpecs)
+	}
+
 	if in.SlotsPerWorker != nil {
 		att["slots_per_worker"] = int(*in.SlotsPerWorker)
 	}
@@ -29,9 +29,10 @@ func paddleJobReplicaSpecTemplateFields() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"restart_policy": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
-			Default:  "Never",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Never",
+			ValidateFunc: kubernetes.ValidateRestartPolicy,
 		},
 	}
 }
@@ -3,15 +3,17 @@ package paddle_job
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
 )
 
 func runPolicyFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"clean_pod_policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "Running",
-			Description: "CleanPodPolicy defines the policy to kill pods after the job completes.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Running",
+			ValidateFunc: kubernetes.ValidateCleanPodPolicy,
+			Description:  "CleanPodPolicy defines the policy to kill pods after the job completes.",
 		},
 		"ttl_seconds_after_finished": {
 			Type:        schema.TypeInt,
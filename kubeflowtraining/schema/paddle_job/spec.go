@@ -3,6 +3,7 @@ package paddle_job
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
 )
 
 func paddleJobSpecFields() map[string]*schema.Schema {
@@ -26,9 +27,10 @@ func paddleJobSpecFields() map[string]*schema.Schema {
 			},
 		},
 		"paddle_replica_specs": {
-			Type:        schema.TypeMap,
-			Description: "A map of PaddleReplicaType (type) to ReplicaSpec (value). Specifies the Paddle cluster configuration.",
-			Optional:    true,
+			Type:         schema.TypeMap,
+			Description:  "A map of PaddleReplicaType (type) to ReplicaSpec (value). Specifies the Paddle cluster configuration.",
+			Optional:     true,
+			ValidateFunc: kubernetes.ValidateReplicaTypes("Master", "Worker"),
 			Elem: &schema.Resource{
 				Schema: paddleJobReplicaSpecFields(),
 			},
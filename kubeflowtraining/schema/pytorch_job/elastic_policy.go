@@ -2,8 +2,10 @@ package pytorch_job
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 )
 
@@ -20,9 +22,10 @@ func elasticPolicyFields() map[string]*schema.Schema {
 			Optional:    true,
 		},
 		"rdzv_backend": {
-			Type:        schema.TypeString,
-			Description: "RDZVBackend is the rendezvous backend to use.",
-			Optional:    true,
+			Type:         schema.TypeString,
+			Description:  "RDZVBackend is the rendezvous backend to use: c10d, etcd or etcd-v2.",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"c10d", "etcd", "etcd-v2"}, false),
 		},
 		"rdzv_port": {
 			Type:        schema.TypeInt,
@@ -67,115 +70,103 @@ func elasticPolicyFields() map[string]*schema.Schema {
 
 }
 
-func expandElasticPolicy(elasticPolicy interface{}) (*kubeflowv1.ElasticPolicy, error) {
-	if elasticPolicy == nil {
-		return nil, nil
-	}
-	elasticPolicyMap := elasticPolicy.(map[string]interface{})
-	elasticPolicyObj := &kubeflowv1.ElasticPolicy{}
-	if v, ok := elasticPolicyMap["min_replicas"].(int32); ok {
-		elasticPolicyObj.MinReplicas = &v
+// expandElasticPolicy expands the elastic_policy block l. Unset fields read
+// as zero values and are left for the operator to default.
+func expandElasticPolicy(l []interface{}) (*kubeflowv1.ElasticPolicy, error) {
+	obj := &kubeflowv1.ElasticPolicy{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
 	}
+	in := l[0].(map[string]interface{})
 
-	if v, ok := elasticPolicyMap["max_replicas"].(int32); ok {
-		elasticPolicyObj.MaxReplicas = &v
-	}
-
-	if v, ok := elasticPolicyMap["rdzv_backend"].(string); ok {
-
-		switch v {
-		case "c10d":
-			*elasticPolicyObj.RDZVBackend = kubeflowv1.BackendC10D
-		case "etcd":
-			*elasticPolicyObj.RDZVBackend = kubeflowv1.BackendETCD
-		case "etcd-v2":
-			*elasticPolicyObj.RDZVBackend = kubeflowv1.BackendETCDV2
-		default:
-			return nil, fmt.Errorf("invalid rdzv_backend %s", v)
+	int32Ptr := func(key string) *int32 {
+		if v, ok := in[key].(int); ok && v != 0 {
+			i := int32(v)
+			return &i
 		}
-
-	}
-
-	if v, ok := elasticPolicyMap["rdzv_port"].(int32); ok {
-		elasticPolicyObj.RDZVPort = &v
+		return nil
 	}
-
-	if v, ok := elasticPolicyMap["rdzv_host"].(string); ok {
-		elasticPolicyObj.RDZVHost = &v
+	stringPtr := func(key string) *string {
+		if v, ok := in[key].(string); ok && v != "" {
+			return &v
+		}
+		return nil
 	}
 
-	if v, ok := elasticPolicyMap["rdzv_id"].(string); ok {
-		elasticPolicyObj.RDZVID = &v
+	obj.MinReplicas = int32Ptr("min_replicas")
+	obj.MaxReplicas = int32Ptr("max_replicas")
+	if v := stringPtr("rdzv_backend"); v != nil {
+		backend := kubeflowv1.RDZVBackend(*v)
+		switch backend {
+		case kubeflowv1.BackendC10D, kubeflowv1.BackendETCD, kubeflowv1.BackendETCDV2:
+			obj.RDZVBackend = &backend
+		default:
+			return nil, fmt.Errorf("invalid rdzv_backend %s", *v)
+		}
 	}
-
-	if v, ok := elasticPolicyMap["rdzv_conf"].([]interface{}); ok {
-		elasticPolicyObj.RDZVConf = make([]kubeflowv1.RDZVConf, len(v))
-		for i, vv := range v {
-			// vv should be map[string]interface{}
-			vvv := vv.(map[string]interface{})
-			for k, vvvv := range vvv {
-				elasticPolicyObj.RDZVConf[i].Key = k
-				elasticPolicyObj.RDZVConf[i].Value = vvvv.(string)
+	obj.RDZVPort = int32Ptr("rdzv_port")
+	obj.RDZVHost = stringPtr("rdzv_host")
+	obj.RDZVID = stringPtr("rdzv_id")
+	if v, ok := in["rdzv_conf"].([]interface{}); ok {
+		for _, conf := range v {
+			m, _ := conf.(map[string]interface{})
+			keys := make([]string, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				obj.RDZVConf = append(obj.RDZVConf, kubeflowv1.RDZVConf{Key: k, Value: fmt.Sprintf("%v", m[k])})
 			}
 		}
 	}
-
-	if v, ok := elasticPolicyMap["standalone"].(bool); ok {
-		elasticPolicyObj.Standalone = &v
+	if v, ok := in["standalone"].(bool); ok && v {
+		obj.Standalone = &v
 	}
+	obj.NProcPerNode = int32Ptr("nproc_per_node")
+	obj.MaxRestarts = int32Ptr("max_restarts")
 
-	if v, ok := elasticPolicyMap["nproc_per_node"].(int32); ok {
-		elasticPolicyObj.NProcPerNode = &v
-	}
-
-	if v, ok := elasticPolicyMap["max_restarts"].(int32); ok {
-		elasticPolicyObj.MaxRestarts = &v
-	}
-
-	return elasticPolicyObj, nil
-
+	return obj, nil
 }
 
-func flattenElasticPolicy(elasticPolicy *kubeflowv1.ElasticPolicy) interface{} {
-	if elasticPolicy == nil {
-		return nil
+func flattenElasticPolicy(in *kubeflowv1.ElasticPolicy) []interface{} {
+	if in == nil {
+		return []interface{}{}
 	}
-	elasticPolicyMap := make(map[string]interface{})
-	if elasticPolicy.MinReplicas != nil {
-		elasticPolicyMap["min_replicas"] = *elasticPolicy.MinReplicas
+	att := make(map[string]interface{})
+	if in.MinReplicas != nil {
+		att["min_replicas"] = int(*in.MinReplicas)
 	}
-	if elasticPolicy.MaxReplicas != nil {
-		elasticPolicyMap["max_replicas"] = *elasticPolicy.MaxReplicas
+	if in.MaxReplicas != nil {
+		att["max_replicas"] = int(*in.MaxReplicas)
 	}
-	if elasticPolicy.RDZVBackend != nil {
-		elasticPolicyMap["rdzv_backend"] = *elasticPolicy.RDZVBackend
+	if in.RDZVBackend != nil {
+		att["rdzv_backend"] = string(*in.RDZVBackend)
 	}
-	if elasticPolicy.RDZVPort != nil {
-		elasticPolicyMap["rdzv_port"] = *elasticPolicy.RDZVPort
+	if in.RDZVPort != nil {
+		att["rdzv_port"] = int(*in.RDZVPort)
 	}
-	if elasticPolicy.RDZVHost != nil {
-		elasticPolicyMap["rdzv_host"] = *elasticPolicy.RDZVHost
+	if in.RDZVHost != nil {
+		att["rdzv_host"] = *in.RDZVHost
 	}
-	if elasticPolicy.RDZVID != nil {
-		elasticPolicyMap["rdzv_id"] = *elasticPolicy.RDZVID
+	if in.RDZVID != nil {
+		att["rdzv_id"] = *in.RDZVID
 	}
-	if elasticPolicy.RDZVConf != nil {
-		rdzvConf := make([]interface{}, len(elasticPolicy.RDZVConf))
-		for i, v := range elasticPolicy.RDZVConf {
-			rdzvConf[i] = map[string]interface{}{
-				v.Key: v.Value,
-			}
+	if len(in.RDZVConf) > 0 {
+		rdzvConf := make([]interface{}, len(in.RDZVConf))
+		for i, v := range in.RDZVConf {
+			rdzvConf[i] = map[string]interface{}{v.Key: v.Value}
 		}
-		elasticPolicyMap["rdzv_conf"] = rdzvConf
+		att["rdzv_conf"] = rdzvConf
 	}
-	if elasticPolicy.Standalone != nil {
-		elasticPolicyMap["standalone"] = *elasticPolicy.Standalone
+	if in.Standalone != nil {
+		att["standalone"] = *in.Standalone
 	}
-	if elasticPolicy.NProcPerNode != nil {
-		elasticPolicyMap["nproc_per_node"] = *elasticPolicy.NProcPerNode
+	if in.NProcPerNode != nil {
+		att["nproc_per_node"] = int(*in.NProcPerNode)
 	}
-	if elasticPolicy.MaxRestarts != nil {
-		elasticPolicyMap["max_restarts"] = *elasticPolicy.MaxRestarts
+	if in.MaxRestarts != nil {
+		att["max_restarts"] = int(*in.MaxRestarts)
 	}
-	return elasticPolicyMap
+	return []interface{}{att}
 }
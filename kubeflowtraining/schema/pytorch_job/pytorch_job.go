@@ -1,6 +1,8 @@
 package pytorch_job
 
 import (
+	"reflect"
+
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
@@ -9,9 +11,19 @@ import (
 
 func PyTorchJobFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"metadata": kubernetes.NamespacedMetadataSchema("PyTorchJob", false),
-		"spec":     pyTorchJobSpecSchema(),
-		"status":   pyTorchJobStatusSchema(),
+		"metadata":                      kubernetes.NamespacedMetadataSchema("PyTorchJob", false),
+		"spec":                          pyTorchJobSpecSchema(),
+		"status":                        pyTorchJobStatusSchema(),
+		"delete_options":                kubernetes.DeleteOptionsSchema(),
+		"deletion_protection":           kubernetes.DeletionProtectionSchema(),
+		"prevent_destroy_while_running": kubernetes.PreventDestroyWhileRunningSchema(),
+		"force_destroy":                 kubernetes.ForceDestroySchema(),
+		"outputs":                       kubernetes.OutputsSchema(),
+		"outputs_replica_type":          kubernetes.OutputsReplicaTypeSchema(),
+		"adopt_existing":                kubernetes.AdoptExistingSchema(),
+		"kueue":                         kubernetes.KueueSchema(),
+		"quota_preflight":               kubernetes.QuotaPreflightSchema(),
+		"wait_for_completion":           kubernetes.WaitForCompletionSchema(),
 	}
 }
 
@@ -59,6 +71,7 @@ func FromResourceData(resourceData *schema.ResourceData) (*kubeflowv1.PyTorchJob
 	result := &kubeflowv1.PyTorchJob{}
 
 	result.ObjectMeta = kubernetes.ExpandMetadata(resourceData.Get("metadata").([]interface{}))
+	kubernetes.ExpandKueue(resourceData.Get("kueue").([]interface{}), &result.ObjectMeta)
 	spec, err := expandPyTorchJobSpec(resourceData.Get("spec").([]interface{}))
 	if err != nil {
 		return result, err
@@ -74,6 +87,14 @@ func FromResourceData(resourceData *schema.ResourceData) (*kubeflowv1.PyTorchJob
 }
 
 func ToResourceData(vm kubeflowv1.PyTorchJob, resourceData *schema.ResourceData) error {
+	if prior, err := FromResourceData(resourceData); err == nil {
+		vm = *vm.DeepCopy()
+		pruneDefaults(prior, &vm)
+	}
+
+	if err := resourceData.Set("kueue", kubernetes.FlattenKueue(&vm.ObjectMeta, resourceData.Get("kueue").([]interface{}), resourceData.Get("metadata.0.labels").(map[string]interface{}))); err != nil {
+		return err
+	}
 	if err := resourceData.Set("metadata", kubernetes.FlattenMetadata(vm.ObjectMeta)); err != nil {
 		return err
 	}
@@ -87,6 +108,41 @@ func ToResourceData(vm kubeflowv1.PyTorchJob, resourceData *schema.ResourceData)
 	return nil
 }
 
+// pruneDefaults unsets the fields of live that the operator defaulted because
+// prior, the job as configured, left them unset.
+func pruneDefaults(prior *kubeflowv1.PyTorchJob, live *kubeflowv1.PyTorchJob) {
+	defaulted := prior.DeepCopy()
+	for t, spec := range defaulted.Spec.PyTorchReplicaSpecs {
+		// The defaulting assumes every replica has a container.
+		if spec == nil || len(spec.Template.Spec.Containers) == 0 {
+			delete(defaulted.Spec.PyTorchReplicaSpecs, t)
+		}
+	}
+	if _, ok := defaulted.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeWorker]; !ok {
+		defaulted.Spec.ElasticPolicy = nil
+	}
+	kubeflowv1.SetDefaults_PyTorchJob(defaulted)
+
+	if prior.Spec.RunPolicy.CleanPodPolicy == nil && reflect.DeepEqual(live.Spec.RunPolicy.CleanPodPolicy, defaulted.Spec.RunPolicy.CleanPodPolicy) {
+		live.Spec.RunPolicy.CleanPodPolicy = nil
+	}
+	if p, d, l := prior.Spec.ElasticPolicy, defaulted.Spec.ElasticPolicy, live.Spec.ElasticPolicy; p != nil && d != nil && l != nil {
+		if p.MinReplicas == nil && reflect.DeepEqual(l.MinReplicas, d.MinReplicas) {
+			l.MinReplicas = nil
+		}
+		if p.MaxReplicas == nil && reflect.DeepEqual(l.MaxReplicas, d.MaxReplicas) {
+			l.MaxReplicas = nil
+		}
+	}
+	for t, spec := range live.Spec.PyTorchReplicaSpecs {
+		p, d := prior.Spec.PyTorchReplicaSpecs[t], defaulted.Spec.PyTorchReplicaSpecs[t]
+		if spec == nil || p == nil || d == nil {
+			continue
+		}
+		kubernetes.PruneDefaultedPodSpec(&p.Template.Spec, &d.Template.Spec, &spec.Template.Spec)
+	}
+}
+
 func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation) patch.PatchOperations {
-	return kubernetes.AppendPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData, ops)
+	return kubernetes.AppendJobPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData.Get(keyPrefix+"kueue").([]interface{}), resourceData, ops)
 }
@@ -0,0 +1,182 @@
+package pytorch_job
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils/patch"
+)
+
+func TestToResourceDataPrunesDefaults(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, PyTorchJobFields(), map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name": "test",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"pytorch_replica_specs": []interface{}{map[string]interface{}{
+				"master": []interface{}{map[string]interface{}{
+					"template": []interface{}{map[string]interface{}{
+						"spec": []interface{}{map[string]interface{}{
+							"container": []interface{}{map[string]interface{}{
+								"name":  "pytorch",
+								"image": "pytorch:latest",
+							}},
+						}},
+					}},
+				}},
+			}},
+		}},
+	})
+
+	job, err := FromResourceData(resourceData)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	kubeflowv1.SetDefaults_PyTorchJob(job)
+	master := job.Spec.PyTorchReplicaSpecs[kubeflowv1.PyTorchJobReplicaTypeMaster]
+	if job.Spec.RunPolicy.CleanPodPolicy == nil || len(master.Template.Spec.Containers[0].Ports) != 1 {
+		t.Fatalf("expected the operator defaulting to set a clean pod policy and port")
+	}
+
+	if err := ToResourceData(*job, resourceData); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v := resourceData.Get("spec.0.run_policy").([]interface{}); len(v) != 0 {
+		t.Fatalf("expected the defaulted run policy to be pruned, got %v", v)
+	}
+	if v := resourceData.Get("spec.0.pytorch_replica_specs.0.master.0.template.0.spec.0.container.0.port").([]interface{}); len(v) != 0 {
+		t.Fatalf("expected the defaulted port to be pruned, got %v", v)
+	}
+	if len(master.Template.Spec.Containers[0].Ports) != 1 {
+		t.Fatalf("expected the job itself to be left untouched")
+	}
+}
+
+func TestElasticPolicy(t *testing.T) {
+	container := []interface{}{map[string]interface{}{
+		"template": []interface{}{map[string]interface{}{
+			"spec": []interface{}{map[string]interface{}{
+				"container": []interface{}{map[string]interface{}{
+					"name":  "pytorch",
+					"image": "pytorch:latest",
+				}},
+			}},
+		}},
+	}}
+	worker := []interface{}{map[string]interface{}{
+		"replicas": 4,
+		"template": container[0].(map[string]interface{})["template"],
+	}}
+	resourceData := schema.TestResourceDataRaw(t, PyTorchJobFields(), map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{
+			"name": "test",
+		}},
+		"spec": []interface{}{map[string]interface{}{
+			"elastic_policy": []interface{}{map[string]interface{}{
+				"rdzv_backend": "c10d",
+				"max_restarts": 3,
+			}},
+			"pytorch_replica_specs": []interface{}{map[string]interface{}{
+				"master": container,
+				"worker": worker,
+			}},
+		}},
+	})
+
+	job, err := FromResourceData(resourceData)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ep := job.Spec.ElasticPolicy
+	if ep == nil || ep.RDZVBackend == nil || *ep.RDZVBackend != kubeflowv1.BackendC10D || ep.MaxRestarts == nil || *ep.MaxRestarts != 3 {
+		t.Fatalf("unexpected elastic policy %+v", ep)
+	}
+	if ep.MinReplicas != nil || ep.RDZVPort != nil || ep.Standalone != nil {
+		t.Fatalf("expected unset fields to stay unset, got %+v", ep)
+	}
+
+	// The operator defaults min and max replicas to the worker replicas.
+	kubeflowv1.SetDefaults_PyTorchJob(job)
+	if err := ToResourceData(*job, resourceData); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []interface{}{map[string]interface{}{
+		"min_replicas":   0,
+		"max_replicas":   0,
+		"rdzv_backend":   "c10d",
+		"rdzv_port":      0,
+		"rdzv_host":      "",
+		"rdzv_id":        "",
+		"rdzv_conf":      []interface{}{},
+		"standalone":     false,
+		"nproc_per_node": 0,
+		"max_restarts":   3,
+	}}
+	if v := resourceData.Get("spec.0.elastic_policy"); !reflect.DeepEqual(v, expected) {
+		t.Fatalf("expected elastic policy %v, got %v", expected, v)
+	}
+}
+
+func TestAppendPatchOpsKeepsKueueLabels(t *testing.T) {
+	config := func(labels map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{
+				"name":   "test",
+				"labels": labels,
+			}},
+			"kueue": []interface{}{map[string]interface{}{
+				"queue_name": "research",
+			}},
+		}
+	}
+	prior := schema.TestResourceDataRaw(t, PyTorchJobFields(), config(nil))
+	prior.SetId("default/test")
+	state := prior.State()
+
+	fields := schema.InternalMap(PyTorchJobFields())
+	diff, err := fields.Diff(state, terraform.NewResourceConfigRaw(config(map[string]interface{}{"team": "ml"})), nil, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resourceData, err := fields.Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Adding a label must not replace the labels object, which holds the
+	// Kueue labels.
+	ops := AppendPatchOps("", "", resourceData, []patch.PatchOperation{})
+	expected := []patch.PatchOperation{&patch.AddOperation{
+		Path:  "/metadata/labels/team",
+		Value: "ml",
+	}}
+	if !ops.Equal(expected) {
+		t.Fatalf("expected %v, got %v", patch.PatchOperations(expected), ops)
+	}
+}
+
+func TestToResourceDataImportsKueueLabels(t *testing.T) {
+	// An imported job has no config nor state yet.
+	resourceData := schema.TestResourceDataRaw(t, PyTorchJobFields(), map[string]interface{}{})
+
+	job := kubeflowv1.PyTorchJob{}
+	job.Name = "test"
+	job.Labels = map[string]string{"team": "ml", kubernetes.KueueQueueNameLabel: "research"}
+	if err := ToResourceData(job, resourceData); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []interface{}{map[string]interface{}{"queue_name": "research", "priority_class": ""}}
+	if actual := resourceData.Get("kueue"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected kueue %#v, got %#v", expected, actual)
+	}
+	expectedLabels := map[string]interface{}{"team": "ml"}
+	if actual := resourceData.Get("metadata.0.labels"); !reflect.DeepEqual(actual, expectedLabels) {
+		t.Errorf("expected labels %#v, got %#v", expectedLabels, actual)
+	}
+}
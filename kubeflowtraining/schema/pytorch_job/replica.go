@@ -1,8 +1,6 @@
 package pytorch_job
 
 import (
-	"strings"
-
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
@@ -10,17 +8,18 @@ import (
 
 func pyTorchJobReplicaSpecFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"master": pyTorchJobReplicaSpecSchema(),
-		"worker": pyTorchJobReplicaSpecSchema(),
+		"master": pyTorchJobReplicaSpecSchema(kubernetes.ValidateSingleReplica),
+		"worker": pyTorchJobReplicaSpecSchema(nil),
 	}
 }
 
-func pyTorchJobReplicaSpecTemplateFields() map[string]*schema.Schema {
+func pyTorchJobReplicaSpecTemplateFields(validateReplicas schema.SchemaValidateFunc) map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"replicas": {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  1,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validateReplicas,
 		},
 		"template": {
 			Type:     schema.TypeList,
@@ -31,20 +30,23 @@ func pyTorchJobReplicaSpecTemplateFields() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"restart_policy": {
-			Type:     schema.TypeString,
-			Optional: true,
-			Default:  "Never",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Never",
+			ValidateFunc: kubernetes.ValidateRestartPolicy,
 		},
+		"accelerator": kubernetes.AcceleratorSchema(),
 	}
 }
 
-func pyTorchJobReplicaSpecSchema() *schema.Schema {
+func pyTorchJobReplicaSpecSchema(validateReplicas schema.SchemaValidateFunc) *schema.Schema {
 	return &schema.Schema{
 		Type: schema.TypeList,
 		Elem: &schema.Resource{
-			Schema: pyTorchJobReplicaSpecTemplateFields(),
+			Schema: pyTorchJobReplicaSpecTemplateFields(validateReplicas),
 		},
 		Optional: true,
+		MaxItems: 1,
 	}
 }
 
@@ -55,20 +57,19 @@ func expandPyTorchJobReplicaSpec(l []interface{}) (map[commonv1.ReplicaType]*com
 
 	m := make(map[commonv1.ReplicaType]*commonv1.ReplicaSpec)
 	for k, v := range l[0].(map[string]interface{}) {
-		if !strings.EqualFold(k, "master") && !strings.EqualFold(k, "worker") {
+		t, ok := kubernetes.ReplicaTypeName(k, "Master", "Worker")
+		if !ok {
 			continue
 		}
-		if k == "master" {
-			k = "Master"
-		} else {
-			k = "Worker"
-		}
 
-		replicaType := commonv1.ReplicaType(k)
+		replicaType := commonv1.ReplicaType(t)
 		replicaSpec, err := expandReplicaSpec(v.([]interface{}))
 		if err != nil {
 			return nil, err
 		}
+		if replicaSpec == nil {
+			continue
+		}
 
 		m[replicaType] = replicaSpec
 	}
@@ -86,6 +87,9 @@ func expandReplicaSpec(l []interface{}) (*commonv1.ReplicaSpec, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := kubernetes.ExpandAccelerator(m["accelerator"].([]interface{}), template); err != nil {
+		return nil, err
+	}
 	restartPolicy := m["restart_policy"].(string)
 
 	return &commonv1.ReplicaSpec{
@@ -97,26 +101,33 @@ func expandReplicaSpec(l []interface{}) (*commonv1.ReplicaSpec, error) {
 
 func flattenReplicaSpec(in *commonv1.ReplicaSpec) ([]interface{}, error) {
 	if in == nil {
-		return []interface{}{nil}, nil
+		return []interface{}{}, nil
 	}
 
-	replicas := in.Replicas
-	template, err := kubernetes.FlattenPodTemplateSpec(in.Template)
+	replicas := 0
+	if in.Replicas != nil {
+		replicas = int(*in.Replicas)
+	}
+	// Take what the accelerator block added out of a copy of the template.
+	podTemplate := in.Template.DeepCopy()
+	accelerator := kubernetes.FlattenAccelerator(podTemplate)
+	template, err := kubernetes.FlattenPodTemplateSpec(*podTemplate)
 	if err != nil {
 		return nil, err
 	}
-	restartPolicy := in.RestartPolicy
+	restartPolicy := string(in.RestartPolicy)
 
 	return []interface{}{map[string]interface{}{
 		"replicas":       replicas,
 		"template":       template,
 		"restart_policy": restartPolicy,
+		"accelerator":    accelerator,
 	}}, nil
 }
 
 func flattenPyTorchJobReplicaSpec(in map[commonv1.ReplicaType]*commonv1.ReplicaSpec) ([]interface{}, error) {
 	if in == nil {
-		return []interface{}{nil}, nil
+		return []interface{}{}, nil
 	}
 
 	m := make(map[string]interface{})
@@ -3,15 +3,18 @@ package pytorch_job
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/utils"
 )
 
 func runPolicyFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"clean_pod_policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "Running",
-			Description: "CleanPodPolicy defines the policy to kill pods after the job completes.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Running",
+			ValidateFunc: kubernetes.ValidateCleanPodPolicy,
+			Description:  "CleanPodPolicy defines the policy to kill pods after the job completes.",
 		},
 		"ttl_seconds_after_finished": {
 			Type:        schema.TypeInt,
@@ -48,11 +51,7 @@ func runPolicyFields() map[string]*schema.Schema {
 						Optional:    true,
 						Description: "Queue is the name of the queue to schedule the job to.",
 					},
-					"min_resources": {
-						Type:        schema.TypeMap,
-						Optional:    true,
-						Description: "MinResources is the minimum resources required for scheduling.",
-					},
+					"min_resources": kubernetes.ResourceListSchema("MinResources is the minimum resources required for scheduling, e.g. the sum of the requests of the pods of the gang."),
 					"priority_class": {
 						Type:        schema.TypeString,
 						Optional:    true,
@@ -69,52 +68,63 @@ func runPolicyFields() map[string]*schema.Schema {
 	}
 }
 
-func expandRunPolicy(runPolicy interface{}) (*commonv1.RunPolicy, error) {
-	if runPolicy == nil {
-		return nil, nil
-	}
+func expandRunPolicy(l []interface{}) (*commonv1.RunPolicy, error) {
 	rp := &commonv1.RunPolicy{}
-	m := runPolicy.(map[string]interface{})
-	if v, ok := m["clean_pod_policy"]; ok {
-		*rp.CleanPodPolicy = commonv1.CleanPodPolicy(v.(string))
+	if len(l) == 0 || l[0] == nil {
+		return rp, nil
+	}
+	m := l[0].(map[string]interface{})
+
+	// The integer fields default to 0 in the schema, which leaves them unset.
+	if v, ok := m["clean_pod_policy"].(string); ok && v != "" {
+		policy := commonv1.CleanPodPolicy(v)
+		rp.CleanPodPolicy = &policy
 	}
-	if v, ok := m["ttl_seconds_after_finished"]; ok {
-		*rp.TTLSecondsAfterFinished = int32(v.(int))
+	if v, ok := m["ttl_seconds_after_finished"].(int); ok && v != 0 {
+		rp.TTLSecondsAfterFinished = utils.PtrToInt32(int32(v))
 	}
-	if v, ok := m["active_deadline_seconds"]; ok {
-		*rp.ActiveDeadlineSeconds = int64(v.(int))
+	if v, ok := m["active_deadline_seconds"].(int); ok && v != 0 {
+		rp.ActiveDeadlineSeconds = utils.PtrToInt64(int64(v))
 	}
-	if v, ok := m["backoff_limit"]; ok {
-		*rp.BackoffLimit = int32(v.(int))
+	if v, ok := m["backoff_limit"].(int); ok && v != 0 {
+		rp.BackoffLimit = utils.PtrToInt32(int32(v))
 	}
-	if v, ok := m["scheduling_policy"]; ok {
-		rp.SchedulingPolicy = expandSchedulingPolicy(v)
+	if v, ok := m["scheduling_policy"].([]interface{}); ok {
+		sp, err := expandSchedulingPolicy(v)
+		if err != nil {
+			return nil, err
+		}
+		rp.SchedulingPolicy = sp
 	}
 	return rp, nil
 }
 
-func expandSchedulingPolicy(v interface{}) *commonv1.SchedulingPolicy {
-	if v == nil {
-		return nil
+func expandSchedulingPolicy(l []interface{}) (*commonv1.SchedulingPolicy, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
 	}
-	m := v.([]interface{})[0].(map[string]interface{})
+	m := l[0].(map[string]interface{})
 	sp := &commonv1.SchedulingPolicy{}
-	if v, ok := m["min_available"]; ok {
-		*sp.MinAvailable = int32(v.(int))
+	if v, ok := m["min_available"].(int); ok && v != 0 {
+		sp.MinAvailable = utils.PtrToInt32(int32(v))
+	}
+	if v, ok := m["queue"].(string); ok {
+		sp.Queue = v
 	}
-	if v, ok := m["queue"]; ok {
-		sp.Queue = v.(string)
+	if v, ok := m["min_resources"].(map[string]interface{}); ok && len(v) > 0 {
+		minResources, err := utils.ExpandMapToResourceList(v)
+		if err != nil {
+			return nil, err
+		}
+		sp.MinResources = minResources
 	}
-	// if v, ok := m["min_resources"]; ok {
-	// 	*sp.MinResources = expandResources(v)
-	// }
-	if v, ok := m["priority_class"]; ok {
-		sp.PriorityClass = v.(string)
+	if v, ok := m["priority_class"].(string); ok {
+		sp.PriorityClass = v
 	}
-	if v, ok := m["schedule_timeout_seconds"]; ok {
-		*sp.ScheduleTimeoutSeconds = int32(v.(int))
+	if v, ok := m["schedule_timeout_seconds"].(int); ok && v != 0 {
+		sp.ScheduleTimeoutSeconds = utils.PtrToInt32(int32(v))
 	}
-	return sp
+	return sp, nil
 }
 
 func flattenSchedulingPolicy(sp *commonv1.SchedulingPolicy) []interface{} {
@@ -128,9 +138,9 @@ func flattenSchedulingPolicy(sp *commonv1.SchedulingPolicy) []interface{} {
 	if sp.Queue != "" {
 		m["queue"] = sp.Queue
 	}
-	// if sp.MinResources != nil {
-	// 	m["min_resources"] = flattenResources(sp.MinResources)
-	// }
+	if sp.MinResources != nil {
+		m["min_resources"] = utils.FlattenStringMap(utils.FlattenResourceList(*sp.MinResources))
+	}
 	if sp.PriorityClass != "" {
 		m["priority_class"] = sp.PriorityClass
 	}
@@ -140,11 +150,8 @@ func flattenSchedulingPolicy(sp *commonv1.SchedulingPolicy) []interface{} {
 	return []interface{}{m}
 }
 
-func flattenRunPolicy(rp *commonv1.RunPolicy) map[string]interface{} {
+func flattenRunPolicy(rp commonv1.RunPolicy) []interface{} {
 	m := map[string]interface{}{}
-	if rp == nil {
-		return m
-	}
 	if rp.CleanPodPolicy != nil {
 		m["clean_pod_policy"] = string(*rp.CleanPodPolicy)
 	}
@@ -160,5 +167,8 @@ func flattenRunPolicy(rp *commonv1.RunPolicy) map[string]interface{} {
 	if rp.SchedulingPolicy != nil {
 		m["scheduling_policy"] = flattenSchedulingPolicy(rp.SchedulingPolicy)
 	}
-	return m
+	if len(m) == 0 {
+		return []interface{}{}
+	}
+	return []interface{}{m}
 }
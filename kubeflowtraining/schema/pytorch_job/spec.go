@@ -11,17 +11,19 @@ import (
 func pyTorchJobSpecFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"run_policy": {
-			Type:        schema.TypeMap,
+			Type:        schema.TypeList,
 			Description: "RunPolicy is a policy for how to run a job.",
 			Optional:    true,
+			MaxItems:    1,
 			Elem: &schema.Resource{
 				Schema: runPolicyFields(),
 			},
 		},
 		"elastic_policy": {
-			Type:        schema.TypeMap,
-			Description: "ElasticPolicy is a policy for elastic distributed training.",
+			Type:        schema.TypeList,
+			Description: "ElasticPolicy is a policy for elastic distributed training. Any elastic_policy block, even an empty one, makes the job elastic.",
 			Optional:    true,
+			MaxItems:    1,
 			Elem: &schema.Resource{
 				Schema: elasticPolicyFields(),
 			},
@@ -60,13 +62,21 @@ func expandPyTorchJobSpec(pyTorchJob []interface{}) (kubeflowv1.PyTorchJobSpec,
 	}
 
 	in := pyTorchJob[0].(map[string]interface{})
-	if v, ok := in["run_policy"]; ok {
-		rp, _ := expandRunPolicy(v)
+	if v, ok := in["run_policy"].([]interface{}); ok {
+		rp, err := expandRunPolicy(v)
+		if err != nil {
+			return result, err
+		}
 		result.RunPolicy = *rp
 	}
 
-	if v, ok := in["elastic_policy"]; ok {
-		result.ElasticPolicy, _ = expandElasticPolicy(v.(interface{}))
+	// Any elastic policy, even an empty one, makes the job elastic.
+	if v, ok := in["elastic_policy"].([]interface{}); ok && len(v) > 0 {
+		ep, err := expandElasticPolicy(v)
+		if err != nil {
+			return result, err
+		}
+		result.ElasticPolicy = ep
 	}
 
 	if v, ok := in["pytorch_replica_specs"]; ok {
@@ -79,11 +89,9 @@ func expandPyTorchJobSpec(pyTorchJob []interface{}) (kubeflowv1.PyTorchJobSpec,
 func flattenPyTorchJobSpec(in kubeflowv1.PyTorchJobSpec) []interface{} {
 	att := make(map[string]interface{})
 
-	att["run_policy"] = flattenRunPolicy(&in.RunPolicy)
+	att["run_policy"] = flattenRunPolicy(in.RunPolicy)
 
-	if in.ElasticPolicy != nil {
-		att["elastic_policy"] = flattenElasticPolicy(in.ElasticPolicy)
-	}
+	att["elastic_policy"] = flattenElasticPolicy(in.ElasticPolicy)
 
 	if in.PyTorchReplicaSpecs != nil {
 		att["pytorch_replica_specs"], _ = flattenPyTorchJobReplicaSpec(in.PyTorchReplicaSpecs)
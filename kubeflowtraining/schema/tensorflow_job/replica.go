@@ -32,9 +32,10 @@ func tfJobReplicaSpecTemplateFields() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"restart_policy": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
-			Default:  "Never",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Never",
+			ValidateFunc: kubernetes.ValidateRestartPolicy,
 		},
 	}
 }
@@ -9,9 +9,18 @@ import (
 
 func TFJobFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"metadata": kubernetes.NamespacedMetadataSchema("TFJob", false),
-		"spec":     tfJobSpecSchema(),
-		"status":   tfJobStatusSchema(),
+		"metadata":                      kubernetes.NamespacedMetadataSchema("TFJob", false),
+		"spec":                          tfJobSpecSchema(),
+		"status":                        tfJobStatusSchema(),
+		"delete_options":                kubernetes.DeleteOptionsSchema(),
+		"deletion_protection":           kubernetes.DeletionProtectionSchema(),
+		"prevent_destroy_while_running": kubernetes.PreventDestroyWhileRunningSchema(),
+		"force_destroy":                 kubernetes.ForceDestroySchema(),
+		"outputs":                       kubernetes.OutputsSchema(),
+		"outputs_replica_type":          kubernetes.OutputsReplicaTypeSchema(),
+		"adopt_existing":                kubernetes.AdoptExistingSchema(),
+		"kueue":                         kubernetes.KueueSchema(),
+		"wait_for_completion":           kubernetes.WaitForCompletionSchema(),
 	}
 }
 
@@ -59,6 +68,7 @@ func FromResourceData(resourceData *schema.ResourceData) (*kubeflowv1.TFJob, err
 	result := &kubeflowv1.TFJob{}
 
 	result.ObjectMeta = kubernetes.ExpandMetadata(resourceData.Get("metadata").([]interface{}))
+	kubernetes.ExpandKueue(resourceData.Get("kueue").([]interface{}), &result.ObjectMeta)
 	spec, err := expandTFJobSpec(resourceData.Get("spec").([]interface{}))
 	if err != nil {
 		return result, err
@@ -74,6 +84,9 @@ func FromResourceData(resourceData *schema.ResourceData) (*kubeflowv1.TFJob, err
 }
 
 func ToResourceData(vm kubeflowv1.TFJob, resourceData *schema.ResourceData) error {
+	if err := resourceData.Set("kueue", kubernetes.FlattenKueue(&vm.ObjectMeta, resourceData.Get("kueue").([]interface{}), resourceData.Get("metadata.0.labels").(map[string]interface{}))); err != nil {
+		return err
+	}
 	if err := resourceData.Set("metadata", kubernetes.FlattenMetadata(vm.ObjectMeta)); err != nil {
 		return err
 	}
@@ -88,5 +101,5 @@ func ToResourceData(vm kubeflowv1.TFJob, resourceData *schema.ResourceData) erro
 }
 
 func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation) patch.PatchOperations {
-	return kubernetes.AppendPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData, ops)
+	return kubernetes.AppendJobPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData.Get(keyPrefix+"kueue").([]interface{}), resourceData, ops)
 }
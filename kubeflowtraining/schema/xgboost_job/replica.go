@@ -38,9 +38,10 @@ func xgboostJobReplicaSpecTemplateFields() map[string]*schema.Schema {
 			Optional: true,
 		},
 		"restart_policy": &schema.Schema{
-			Type:     schema.TypeString,
-			Optional: true,
-			Default:  "Never",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Never",
+			ValidateFunc: kubernetes.ValidateRestartPolicy,
 		},
 	}
 }
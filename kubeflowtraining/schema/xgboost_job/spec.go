@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	kubeflowv1 "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"github.com/rh01/terraform-provider-kubeflow-training/kubeflowtraining/schema/kubernetes"
 )
 
 func xgboostJobSpecFields() map[string]*schema.Schema {
@@ -28,9 +29,10 @@ func xgboostJobSpecFields() map[string]*schema.Schema {
 			},
 		},
 		"xgboost_replica_specs": {
-			Type:        schema.TypeMap,
-			Description: "A map of XGBoostReplicaType (type) to ReplicaSpec (value). Specifies the XGBoost cluster configuration.",
-			Optional:    true,
+			Type:         schema.TypeMap,
+			Description:  "A map of XGBoostReplicaType (type) to ReplicaSpec (value). Specifies the XGBoost cluster configuration.",
+			Optional:     true,
+			ValidateFunc: kubernetes.ValidateReplicaTypes("Master", "Worker"),
 			Elem: &schema.Resource{
 				Schema: xgboostJobReplicaSpecFields(),
 			},
@@ -74,6 +74,18 @@ func (po PatchOperations) MarshalJSON() ([]byte, error) {
 	return json.Marshal(v)
 }
 
+// WithResourceVersionTest returns the operations preceded by a test that the
+// object is still at resourceVersion, so that the patch is rejected instead of
+// silently applied over changes made since.
+func (po PatchOperations) WithResourceVersionTest(resourceVersion string) PatchOperations {
+	ops := make([]PatchOperation, 0, len(po)+1)
+	ops = append(ops, &TestOperation{
+		Path:  "/metadata/resourceVersion",
+		Value: resourceVersion,
+	})
+	return append(ops, po...)
+}
+
 func (po PatchOperations) Equal(ops []PatchOperation) bool {
 	var v []PatchOperation = po
 
@@ -152,3 +164,23 @@ func (o *RemoveOperation) String() string {
 	b, _ := o.MarshalJSON()
 	return string(b)
 }
+
+type TestOperation struct {
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+	Op    string      `json:"op"`
+}
+
+func (o *TestOperation) GetPath() string {
+	return o.Path
+}
+
+func (o *TestOperation) MarshalJSON() ([]byte, error) {
+	o.Op = "test"
+	return json.Marshal(*o)
+}
+
+func (o *TestOperation) String() string {
+	b, _ := o.MarshalJSON()
+	return string(b)
+}
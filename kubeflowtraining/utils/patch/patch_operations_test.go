@@ -165,3 +165,21 @@ func TestEscapeJsonPointer(t *testing.T) {
 		}
 	}
 }
+
+func TestWithResourceVersionTest(t *testing.T) {
+	ops := PatchOperations{
+		&ReplaceOperation{
+			Path:  "/metadata/labels/team",
+			Value: "ml",
+		},
+	}.WithResourceVersionTest("42")
+
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := `[{"path":"/metadata/resourceVersion","value":"42","op":"test"},{"path":"/metadata/labels/team","value":"ml","op":"replace"}]`
+	if string(data) != expected {
+		t.Fatalf("Operations don't match.\nExpected: %s\nGiven:    %s\n", expected, data)
+	}
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+const redacted = "<redacted>"
+
+// credentialAnnotation matches the keys of annotations that likely hold a
+// credential.
+var credentialAnnotation = regexp.MustCompile(`(?i)(token|password|passwd|secret|credential|api[-_.]?key|private[-_.]?key|auth)`)
+
+// Redacted returns obj as JSON for logging, with the values of environment
+// variables, secret references and credential-like annotations masked.
+func Redacted(obj interface{}) string {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Sprintf("<unable to log %T: %s>", obj, err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Sprintf("<unable to log %T: %s>", obj, err)
+	}
+	data, err = json.Marshal(redact(v))
+	if err != nil {
+		return fmt.Sprintf("<unable to log %T: %s>", obj, err)
+	}
+	return string(data)
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			switch k {
+			case "env":
+				v[k] = redactEnv(value)
+			case "secretKeyRef", "secretRef":
+				v[k] = redacted
+			case "annotations":
+				v[k] = redactAnnotations(value)
+			default:
+				v[k] = redact(value)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+	return v
+}
+
+func redactEnv(v interface{}) interface{} {
+	envs, ok := v.([]interface{})
+	if !ok {
+		return redact(v)
+	}
+	for _, e := range envs {
+		env, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := env["value"]; ok {
+			env["value"] = redacted
+		}
+		redact(env)
+	}
+	return envs
+}
+
+func redactAnnotations(v interface{}) interface{} {
+	annotations, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	for k := range annotations {
+		if credentialAnnotation.MatchString(k) {
+			annotations[k] = redacted
+		}
+	}
+	return annotations
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRedacted(t *testing.T) {
+	pod := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"example.com/api-token": "annotation-secret",
+				"example.com/team":      "ml",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "pytorch",
+				Env: []corev1.EnvVar{
+					{Name: "API_KEY", Value: "env-secret"},
+					{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "secret-name"},
+							Key:                  "password",
+						},
+					}},
+				},
+			}},
+		},
+	}
+
+	out := Redacted(pod)
+	for _, secret := range []string{"annotation-secret", "env-secret", "secret-name"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("expected %q to be redacted from %s", secret, out)
+		}
+	}
+	for _, kept := range []string{"API_KEY", "PASSWORD", "pytorch", `"ml"`} {
+		if !strings.Contains(out, kept) {
+			t.Fatalf("expected %q to be kept in %s", kept, out)
+		}
+	}
+}
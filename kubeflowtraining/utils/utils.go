@@ -1,5 +1,7 @@
 package utils
 
+import "strings"
+
 func ConvertMap(src map[string]interface{}) map[string]string {
 	result := map[string]string{}
 	for k, v := range src {
@@ -7,3 +9,16 @@ func ConvertMap(src map[string]interface{}) map[string]string {
 	}
 	return result
 }
+
+// RelaxedJSONPath wraps a bare JSONPath expression such as `.status.phase` in
+// the braces the jsonpath template parser expects.
+func RelaxedJSONPath(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "{") {
+		return expr
+	}
+	if !strings.HasPrefix(expr, ".") && !strings.HasPrefix(expr, "[") {
+		expr = "." + expr
+	}
+	return "{" + expr + "}"
+}
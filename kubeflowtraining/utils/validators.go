@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"k8s.io/apimachinery/pkg/api/resource"
 	apiValidation "k8s.io/apimachinery/pkg/api/validation"
 	utilValidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/jsonpath"
 )
 
 func ValidateAnnotations(value interface{}, key string) (ws []string, es []error) {
@@ -310,3 +312,119 @@ func StringIsIntInRange(min int, max int) schema.SchemaValidateFunc {
 		return warnings, errors
 	}
 }
+
+func ValidateDuration(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		es = append(es, fmt.Errorf("%s (%q) is not a valid duration: %s", key, v, err))
+		return
+	}
+	if d <= 0 {
+		es = append(es, fmt.Errorf("%s must be greater than 0", key))
+	}
+	return
+}
+
+func ValidateJSONPath(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if err := jsonpath.New(key).Parse(RelaxedJSONPath(v)); err != nil {
+		es = append(es, fmt.Errorf("%s (%q) is not a valid JSONPath expression: %s", key, v, err))
+	}
+	return
+}
+
+// cronField is the range of a field of a cron expression, with the names it
+// accepts in place of numbers.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+	anyValue bool
+}
+
+// cronFields are the fields of the cron expressions of Kubeflow Pipelines
+// jobs, which start with seconds.
+var cronFields = []cronField{
+	{name: "seconds", min: 0, max: 59},
+	{name: "minutes", min: 0, max: 59},
+	{name: "hours", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31, anyValue: true},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 6, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}, anyValue: true},
+}
+
+// ValidateCronExpression validates a cron expression with seconds, e.g.
+// "0 0/10 * * * ?", or a descriptor such as "@daily" or "@every 1h".
+func ValidateCronExpression(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if strings.HasPrefix(v, "@every ") {
+		if d, err := time.ParseDuration(strings.TrimPrefix(v, "@every ")); err != nil || d <= 0 {
+			es = append(es, fmt.Errorf("%s (%q) is not a valid interval", key, v))
+		}
+		return
+	}
+	switch v {
+	case "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly":
+		return
+	}
+
+	fields := strings.Fields(v)
+	if len(fields) != len(cronFields) {
+		es = append(es, fmt.Errorf("%s (%q) must have %d fields: seconds, minutes, hours, day of month, month and day of week", key, v, len(cronFields)))
+		return
+	}
+	for i, field := range fields {
+		if err := cronFields[i].validate(field); err != nil {
+			es = append(es, fmt.Errorf("%s (%q): %s", key, v, err))
+		}
+	}
+	return
+}
+
+func (f cronField) validate(field string) error {
+	for _, item := range strings.Split(field, ",") {
+		if item == "?" && f.anyValue {
+			continue
+		}
+		values := item
+		if i := strings.Index(item, "/"); i >= 0 {
+			values = item[:i]
+			step, err := strconv.Atoi(item[i+1:])
+			if err != nil || step <= 0 {
+				return fmt.Errorf("invalid step %q in the %s field", item[i+1:], f.name)
+			}
+		}
+		if values == "*" {
+			continue
+		}
+		bounds := strings.SplitN(values, "-", 2)
+		low, err := f.value(bounds[0])
+		if err != nil {
+			return err
+		}
+		if len(bounds) == 2 {
+			high, err := f.value(bounds[1])
+			if err != nil {
+				return err
+			}
+			if low > high {
+				return fmt.Errorf("invalid range %q in the %s field", values, f.name)
+			}
+		}
+	}
+	return nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%q is not between %d and %d in the %s field", s, f.min, f.max, f.name)
+	}
+	return v, nil
+}
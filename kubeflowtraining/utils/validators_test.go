@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestValidateCronExpression(t *testing.T) {
+	valid := []string{
+		"0 0/10 * * * ?",
+		"0 30 9 ? * MON-FRI",
+		"*/15 0 0 1,15 JAN-JUN *",
+		"@daily",
+		"@every 90m",
+	}
+	for _, v := range valid {
+		if _, es := ValidateCronExpression(v, "cron"); len(es) > 0 {
+			t.Errorf("expected %q to be valid, got %v", v, es)
+		}
+	}
+
+	invalid := []string{
+		"0 0 * * *",
+		"0 60 * * * ?",
+		"0 0 0 ? * 7",
+		"? 0 0 * * *",
+		"0 0/0 * * * *",
+		"0 0 10-2 * * *",
+		"@every soon",
+		"@sometimes",
+	}
+	for _, v := range invalid {
+		if _, es := ValidateCronExpression(v, "cron"); len(es) == 0 {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}